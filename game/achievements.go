@@ -0,0 +1,288 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Archivo donde se guardan los logros desbloqueados
+const achievementsFile = "achievements.json"
+
+// Duración de las notificaciones (toasts) en pantalla
+const toastDuration = 4 * time.Second
+
+// AchievementStat es la estadística que evalúa cada logro
+type AchievementStat int
+
+const (
+	StatCatches AchievementStat = iota
+	StatCasts
+	StatEscapes
+	StatLegendaryCaught
+	StatBestStreak
+	StatSecondsPlayed
+)
+
+// Achievement es la definición declarativa de un logro:
+// se desbloquea cuando la estadística Stat llega a Goal
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Stat        AchievementStat
+	Goal        int
+}
+
+// Definiciones de logros
+var achievementDefs = []Achievement{
+	{ID: "first_cast", Name: "Primer Lanzamiento", Description: "Lanza el anzuelo por primera vez", Stat: StatCasts, Goal: 1},
+	{ID: "first_catch", Name: "Primera Captura", Description: "Captura tu primer pez", Stat: StatCatches, Goal: 1},
+	{ID: "catch_25", Name: "Pescador", Description: "Captura 25 peces", Stat: StatCatches, Goal: 25},
+	{ID: "catch_100", Name: "Maestro Pescador", Description: "Captura 100 peces", Stat: StatCatches, Goal: 100},
+	{ID: "casts_50", Name: "Brazo Incansable", Description: "Lanza el anzuelo 50 veces", Stat: StatCasts, Goal: 50},
+	{ID: "escape_10", Name: "Se Fueron", Description: "Deja escapar 10 peces del lago", Stat: StatEscapes, Goal: 10},
+	{ID: "legendary", Name: "Leyenda", Description: "Captura un pez legendario", Stat: StatLegendaryCaught, Goal: 1},
+	{ID: "streak_5", Name: "Racha", Description: "Captura 5 peces seguidos sin recoger vacío", Stat: StatBestStreak, Goal: 5},
+	{ID: "streak_10", Name: "Imparable", Description: "Captura 10 peces seguidos sin recoger vacío", Stat: StatBestStreak, Goal: 10},
+	{ID: "play_10m", Name: "Paciencia", Description: "Juega durante 10 minutos", Stat: StatSecondsPlayed, Goal: 600},
+}
+
+// toast es una notificación de logro desbloqueado
+type toast struct {
+	text    string
+	expires time.Time
+}
+
+// AchievementEngine acumula estadísticas a partir de eventos y desbloquea logros
+type AchievementEngine struct {
+	mu       sync.Mutex
+	saveMu   sync.Mutex // Serializa las escrituras del archivo
+	defs     []Achievement
+	stats    map[AchievementStat]int
+	streak   int
+	unlocked map[string]time.Time
+	toasts   []toast
+	path     string
+
+	toastTexts []string // Buffer reutilizado por DrawToasts (sólo la goroutine de Draw)
+}
+
+// achievementsSave es el formato persistido en disco
+type achievementsSave struct {
+	Stats    map[AchievementStat]int `json:"stats"`
+	Unlocked map[string]time.Time    `json:"unlocked"`
+}
+
+// NewAchievementEngine crea el motor con las definiciones por defecto.
+// Con path vacío el progreso no se persiste.
+func NewAchievementEngine(path string) *AchievementEngine {
+	return &AchievementEngine{
+		defs:     achievementDefs,
+		stats:    make(map[AchievementStat]int),
+		unlocked: make(map[string]time.Time),
+		path:     path,
+	}
+}
+
+// Load carga el progreso guardado (si no existe el archivo no es un error)
+func (a *AchievementEngine) Load() error {
+	if a.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var save achievementsSave
+	if err := json.Unmarshal(data, &save); err != nil {
+		return fmt.Errorf("invalid achievements file %s: %w", a.path, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for stat, value := range save.Stats {
+		a.stats[stat] = value
+	}
+	for id, at := range save.Unlocked {
+		a.unlocked[id] = at
+	}
+	return nil
+}
+
+// Save guarda el progreso en disco
+func (a *AchievementEngine) Save() error {
+	if a.path == "" {
+		return nil
+	}
+	a.saveMu.Lock()
+	defer a.saveMu.Unlock()
+
+	a.mu.Lock()
+	save := achievementsSave{
+		Stats:    make(map[AchievementStat]int, len(a.stats)),
+		Unlocked: make(map[string]time.Time, len(a.unlocked)),
+	}
+	for stat, value := range a.stats {
+		save.Stats[stat] = value
+	}
+	for id, at := range a.unlocked {
+		save.Unlocked[id] = at
+	}
+	a.mu.Unlock()
+
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(a.path, data, 0644)
+}
+
+// HandleEvent actualiza las estadísticas y retorna los logros recién desbloqueados
+func (a *AchievementEngine) HandleEvent(ev GameEvent) []Achievement {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch ev.Type {
+	case EventFishCaught:
+		a.stats[StatCatches]++
+		a.streak++
+		if a.streak > a.stats[StatBestStreak] {
+			a.stats[StatBestStreak] = a.streak
+		}
+		if ev.FishType == FishLegendary {
+			a.stats[StatLegendaryCaught]++
+		}
+	case EventCast:
+		a.stats[StatCasts]++
+	case EventReel:
+		// Recoger el anzuelo sin captura rompe la racha
+		a.streak = 0
+	case EventFishEscaped:
+		a.stats[StatEscapes]++
+	}
+
+	return a.checkUnlocks()
+}
+
+// AddSecondsPlayed suma tiempo jugado y retorna los logros recién desbloqueados
+func (a *AchievementEngine) AddSecondsPlayed(seconds int) []Achievement {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.stats[StatSecondsPlayed] += seconds
+	return a.checkUnlocks()
+}
+
+// checkUnlocks desbloquea los logros cuya meta se alcanzó
+// IMPORTANTE: debe llamarse con a.mu tomado
+func (a *AchievementEngine) checkUnlocks() []Achievement {
+	var newly []Achievement
+	for _, def := range a.defs {
+		if _, done := a.unlocked[def.ID]; done {
+			continue
+		}
+		if a.stats[def.Stat] >= def.Goal {
+			now := time.Now()
+			a.unlocked[def.ID] = now
+			a.toasts = append(a.toasts, toast{
+				text:    "Logro: " + def.Name,
+				expires: now.Add(toastDuration),
+			})
+			newly = append(newly, def)
+		}
+	}
+	return newly
+}
+
+// UnlockedCount retorna cuántos logros se han desbloqueado
+func (a *AchievementEngine) UnlockedCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.unlocked)
+}
+
+// activeToasts agrega a texts los textos de las notificaciones vigentes
+// y descarta las que ya expiraron
+func (a *AchievementEngine) activeToasts(now time.Time, texts []string) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	valid := a.toasts[:0]
+	for _, t := range a.toasts {
+		if now.Before(t.expires) {
+			valid = append(valid, t)
+			texts = append(texts, t.text)
+		}
+	}
+	a.toasts = valid
+	return texts
+}
+
+// DrawToasts dibuja las notificaciones de logros en la esquina superior derecha
+func (a *AchievementEngine) DrawToasts(screen *ebiten.Image) {
+	a.toastTexts = a.activeToasts(time.Now(), a.toastTexts[:0])
+	for i, text := range a.toastTexts {
+		y := 10 + i*30
+		fillRect(screen, ScreenWidth-210, float64(y), 200, 24, colorToast)
+
+		ebitenutil.DebugPrintAt(screen, text, ScreenWidth-202, y+4)
+	}
+}
+
+// ============================================================================
+// CONSUMIDOR: achievementProcessor
+// ============================================================================
+// Esta goroutine lee sus eventos del bus y alimenta al motor de logros.
+// También cuenta el tiempo jugado con su propio ticker y guarda el progreso.
+func (g *Game) achievementProcessor() {
+	defer g.wg.Done()
+	labelGoroutine(g.ctx, "achievements")
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	saveTicker := time.NewTicker(30 * time.Second) // Guardar progreso periódicamente
+	defer saveTicker.Stop()
+
+	for {
+		select {
+		case <-g.ctx.Done():
+			g.saveAchievements()
+			return
+
+		case <-ticker.C:
+			if newly := g.achievements.AddSecondsPlayed(1); len(newly) > 0 {
+				g.saveAchievements()
+			}
+
+		case <-saveTicker.C:
+			g.saveAchievements()
+
+		case ev, ok := <-g.achievementSub.C():
+			if !ok {
+				return
+			}
+			if newly := g.achievements.HandleEvent(ev); len(newly) > 0 {
+				g.saveAchievements()
+			}
+		}
+	}
+}
+
+// saveAchievements guarda el progreso de logros reportando errores por consola
+func (g *Game) saveAchievements() {
+	if err := g.achievements.Save(); err != nil {
+		fmt.Println("Warning: failed to save achievements:", err)
+	}
+}
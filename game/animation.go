@@ -0,0 +1,310 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Archivo con las definiciones de animación (dentro del directorio de assets)
+const animationsFile = "animations.json"
+
+// AnimationDef describe una animación dentro de una hoja de sprites.
+// Los frames están en una fila horizontal: el frame i ocupa la columna Start+i.
+type AnimationDef struct {
+	Sheet       string  `json:"sheet"`        // Nombre del archivo de la hoja
+	FrameWidth  int     `json:"frame_width"`  // 0 = ancho de la hoja / frames
+	FrameHeight int     `json:"frame_height"` // 0 = alto de la hoja
+	Start       int     `json:"start"`        // Columna del primer frame
+	Frames      int     `json:"frames"`       // Cantidad de frames
+	Durations   []int   `json:"durations"`    // Ticks por frame (un solo valor aplica a todos)
+	Loop        bool    `json:"loop"`         // Repetir al terminar
+	OriginX     float64 `json:"origin_x"`     // Punto de anclaje relativo al frame (0.5 = centro)
+	OriginY     float64 `json:"origin_y"`
+
+	// Puntos de anclaje por nombre (ej. "rod_tip"), uno por frame en píxeles
+	// desde la esquina del frame. Si hay menos puntos que frames se repite el último.
+	Anchors map[string][]AnchorPoint `json:"anchors"`
+
+	sheet  *ebiten.Image   // Hoja dentro del atlas (asignada por LoadAnimations)
+	frames []*ebiten.Image // Sub-imágenes de cada frame, precalculadas al cargar
+}
+
+// AnchorPoint es una posición dentro de un frame
+type AnchorPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// duration retorna cuántos ticks dura un frame
+func (d *AnimationDef) duration(frame int) int {
+	if len(d.Durations) == 0 {
+		return 1
+	}
+	if frame < len(d.Durations) {
+		return d.Durations[frame]
+	}
+	return d.Durations[len(d.Durations)-1]
+}
+
+// frameSize retorna el tamaño de un frame, deduciéndolo de la hoja si no está definido
+func (d *AnimationDef) frameSize() (int, int) {
+	w, h := d.FrameWidth, d.FrameHeight
+	if d.sheet != nil {
+		if w == 0 && d.Frames > 0 {
+			w = (d.sheet.Bounds().Dx() / (d.Start + d.Frames))
+		}
+		if h == 0 {
+			h = d.sheet.Bounds().Dy()
+		}
+	}
+	return w, h
+}
+
+// FrameImage retorna la sub-imagen del frame indicado
+func (d *AnimationDef) FrameImage(frame int) *ebiten.Image {
+	if frame < 0 || frame >= len(d.frames) {
+		return nil
+	}
+	return d.frames[frame]
+}
+
+// sliceFrames recorta los frames de la hoja una sola vez, para no crear
+// sub-imágenes en cada Draw
+func (d *AnimationDef) sliceFrames() {
+	d.frames = nil
+	if d.sheet == nil {
+		return
+	}
+	w, h := d.frameSize()
+	origin := d.sheet.Bounds().Min
+	for i := 0; i < d.Frames; i++ {
+		sx := origin.X + (d.Start+i)*w
+		rect := image.Rect(sx, origin.Y, sx+w, origin.Y+h)
+		d.frames = append(d.frames, d.sheet.SubImage(rect).(*ebiten.Image))
+	}
+}
+
+// validate verifica que la definición tenga sentido
+func (d *AnimationDef) validate(name string) error {
+	if d.Sheet == "" {
+		return fmt.Errorf("animation %s: sheet is required", name)
+	}
+	if d.Frames <= 0 {
+		return fmt.Errorf("animation %s: frames must be positive", name)
+	}
+	for _, ticks := range d.Durations {
+		if ticks <= 0 {
+			return fmt.Errorf("animation %s: durations must be positive", name)
+		}
+	}
+	return nil
+}
+
+// defaultAnimations son las definiciones usadas si animations.json falta o es inválido
+var defaultAnimations = map[string]AnimationDef{
+	"player_walk_up":    {Sheet: "fisherman_walk_up.png", Frames: 2, Durations: []int{FrameDelay}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"player_walk_down":  {Sheet: "fisherman_walk_down.png", Frames: 2, Durations: []int{FrameDelay}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"player_walk_left":  {Sheet: "fisherman_walk_left.png", Frames: 2, Durations: []int{FrameDelay}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"player_walk_right": {Sheet: "fisherman_walk_right.png", Frames: 2, Durations: []int{FrameDelay}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"player_fishing": {Sheet: "fisherman_fishing.png", Frames: 3, Durations: []int{FrameDelay}, Loop: true, OriginX: 0.5, OriginY: 0.5,
+		Anchors: map[string][]AnchorPoint{"rod_tip": {{40, 24}, {43, 2}, {43, 45}}}},
+
+	"fish_common":    {Sheet: "fish_common.png", FrameWidth: 32, FrameHeight: 24, Frames: 2, Durations: []int{15}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"fish_rare":      {Sheet: "fish_rare.png", FrameWidth: 36, FrameHeight: 28, Frames: 2, Durations: []int{15}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"fish_epic":      {Sheet: "fish_epic.png", FrameWidth: 40, FrameHeight: 32, Frames: 2, Durations: []int{15}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+	"fish_legendary": {Sheet: "fish_legendary.png", FrameWidth: 50, FrameHeight: 40, Frames: 2, Durations: []int{15}, Loop: true, OriginX: 0.5, OriginY: 0.5},
+
+	"bobber_floating": {Sheet: "bobber.png", FrameWidth: 32, FrameHeight: 32, Start: 0, Frames: 1, OriginX: 0.5, OriginY: 0.5,
+		Anchors: map[string][]AnchorPoint{"line": {{16, 5}}}},
+	"bobber_bite": {Sheet: "bobber.png", FrameWidth: 32, FrameHeight: 32, Start: 1, Frames: 1, OriginX: 0.5, OriginY: 0.5,
+		Anchors: map[string][]AnchorPoint{"line": {{16, 5}}}},
+	"bobber_caught": {Sheet: "bobber.png", FrameWidth: 32, FrameHeight: 32, Start: 2, Frames: 1, OriginX: 0.5, OriginY: 0.5,
+		Anchors: map[string][]AnchorPoint{"line": {{16, 5}}}},
+}
+
+// Animaciones cargadas y el atlas de sus hojas (globales, compartidos por todas las entidades)
+var (
+	animations   map[string]*AnimationDef
+	spriteAtlas  *Atlas
+	animationsMu sync.RWMutex
+)
+
+// ParseAnimations lee definiciones de animación en JSON
+func ParseAnimations(r io.Reader) (map[string]AnimationDef, error) {
+	defs := make(map[string]AnimationDef)
+	if err := json.NewDecoder(r).Decode(&defs); err != nil {
+		return nil, err
+	}
+	for name, def := range defs {
+		if err := def.validate(name); err != nil {
+			return nil, err
+		}
+	}
+	return defs, nil
+}
+
+// LoadAnimations carga animations.json y las hojas de sprites que usa.
+// Las animaciones que no estén en el archivo toman su definición por defecto.
+// Se puede volver a llamar para recargarlas en caliente.
+func LoadAnimations(assets *AssetManager) {
+	defs := make(map[string]AnimationDef, len(defaultAnimations))
+	for name, def := range defaultAnimations {
+		defs[name] = def
+	}
+
+	if f, err := assets.Open(animationsFile); err == nil {
+		parsed, err := ParseAnimations(f)
+		f.Close()
+		if err != nil {
+			fmt.Println("Warning: invalid", animationsFile+", using defaults:", err)
+		}
+		for name, def := range parsed {
+			defs[name] = def
+		}
+	}
+
+	// Empaquetar en el atlas todas las hojas que usan las animaciones
+	sheets := make(map[string]*ebiten.Image)
+	for _, def := range defs {
+		sheets[def.Sheet] = assets.Image(def.Sheet)
+	}
+	atlas := BuildAtlas(sheets)
+
+	loaded := make(map[string]*AnimationDef, len(defs))
+	for name, def := range defs {
+		def.sheet, _ = atlas.Image(def.Sheet)
+		def.sliceFrames()
+		loaded[name] = &def
+	}
+
+	animationsMu.Lock()
+	old := spriteAtlas
+	animations = loaded
+	spriteAtlas = atlas
+	animationsMu.Unlock()
+
+	old.deallocate()
+}
+
+// SpriteAtlas retorna el atlas con las hojas de sprites cargadas
+func SpriteAtlas() *Atlas {
+	animationsMu.RLock()
+	defer animationsMu.RUnlock()
+	return spriteAtlas
+}
+
+// animationDef busca una animación por nombre (nil si no existe o no se cargaron)
+func animationDef(name string) *AnimationDef {
+	animationsMu.RLock()
+	defer animationsMu.RUnlock()
+	return animations[name]
+}
+
+// AnimationPlayer reproduce una animación por nombre. Guarda sólo el nombre,
+// así una recarga de las definiciones se aplica sin tocar las entidades.
+type AnimationPlayer struct {
+	name     string
+	frame    int
+	ticks    int
+	finished bool
+}
+
+// Play cambia de animación; si ya se estaba reproduciendo no la reinicia
+func (a *AnimationPlayer) Play(name string) {
+	if a.name == name {
+		return
+	}
+	a.name = name
+	a.Reset()
+}
+
+// Reset vuelve al primer frame
+func (a *AnimationPlayer) Reset() {
+	a.frame = 0
+	a.ticks = 0
+	a.finished = false
+}
+
+// Name retorna la animación actual
+func (a *AnimationPlayer) Name() string {
+	return a.name
+}
+
+// Frame retorna el frame actual
+func (a *AnimationPlayer) Frame() int {
+	return a.frame
+}
+
+// Finished indica si una animación sin loop llegó al último frame
+func (a *AnimationPlayer) Finished() bool {
+	return a.finished
+}
+
+// Update avanza la animación un tick
+func (a *AnimationPlayer) Update() {
+	def := animationDef(a.name)
+	if def == nil || a.finished {
+		return
+	}
+
+	a.ticks++
+	if a.ticks < def.duration(a.frame) {
+		return
+	}
+	a.ticks = 0
+
+	if a.frame+1 < def.Frames {
+		a.frame++
+	} else if def.Loop {
+		a.frame = 0
+	} else {
+		a.finished = true
+	}
+}
+
+// Anchor retorna la posición en pantalla del punto de anclaje name en el
+// frame actual, si el sprite se dibuja con su origen en (x, y)
+func (a *AnimationPlayer) Anchor(name string, x, y float64) (float64, float64, bool) {
+	def := animationDef(a.name)
+	if def == nil {
+		return x, y, false
+	}
+	points := def.Anchors[name]
+	if len(points) == 0 {
+		return x, y, false
+	}
+	p := points[min(a.frame, len(points)-1)]
+
+	w, h := def.frameSize()
+	return x - float64(w)*def.OriginX + p.X, y - float64(h)*def.OriginY + p.Y, true
+}
+
+// DrawAt dibuja el frame actual con su origen en (x, y).
+// op puede traer color y transformaciones (escala, rotación), que se
+// aplican alrededor del origen del frame antes de posicionarlo.
+func (a *AnimationPlayer) DrawAt(screen *ebiten.Image, x, y float64, op *ebiten.DrawImageOptions) {
+	def := animationDef(a.name)
+	if def == nil {
+		return
+	}
+	img := def.FrameImage(a.frame % def.Frames)
+	if img == nil {
+		return
+	}
+	if op == nil {
+		op = &ebiten.DrawImageOptions{}
+	}
+
+	w, h := def.frameSize()
+	var geo ebiten.GeoM
+	geo.Translate(-float64(w)*def.OriginX, -float64(h)*def.OriginY)
+	geo.Concat(op.GeoM)
+	geo.Translate(x, y)
+	op.GeoM = geo
+	screen.DrawImage(img, op)
+}
@@ -0,0 +1,252 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io/fs"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Cada cuánto se revisa el directorio de assets en busca de cambios
+const assetPollInterval = 500 * time.Millisecond
+
+// Tamaños de los placeholders para que las hojas de sprites se dividan igual
+// que las reales. Los assets que no aparecen aquí usan 32x32.
+var placeholderSizes = map[string]image.Point{
+	"fisherman_walk_up.png":    {96, 48},
+	"fisherman_walk_down.png":  {96, 48},
+	"fisherman_walk_left.png":  {96, 48},
+	"fisherman_walk_right.png": {96, 48},
+	"fisherman_fishing.png":    {144, 48},
+	"fish_common.png":          {64, 24},
+	"fish_rare.png":            {72, 28},
+	"fish_epic.png":            {80, 32},
+	"fish_legendary.png":       {100, 40},
+	"bobber.png":               {96, 32},
+}
+
+// AssetManager es el único punto de acceso a los assets del juego.
+// Busca primero en el directorio de override (para mods) y si el archivo
+// no está ahí usa los assets embebidos en el binario. Las imágenes se
+// guardan en caché; si un archivo falta se usa un placeholder.
+type AssetManager struct {
+	base     fs.FS // Assets embebidos (o ./assets si no hay)
+	override fs.FS // Directorio opcional en disco, nil si no se usa
+
+	overrideDir string
+
+	mu      sync.Mutex
+	cache   map[string]*ebiten.Image
+	missing map[string]bool // Assets ya reportados como faltantes
+
+	// Se incrementa cada vez que cambia algún archivo del override (hot reload)
+	version atomic.Int64
+}
+
+// NewAssetManager crea el gestor. Si base es nil se leen los assets de ./assets;
+// si overrideDir no está vacío sus archivos reemplazan a los de base.
+func NewAssetManager(base fs.FS, overrideDir string) *AssetManager {
+	if base == nil {
+		base = os.DirFS("assets")
+	}
+	m := &AssetManager{
+		base:        base,
+		overrideDir: overrideDir,
+		cache:       make(map[string]*ebiten.Image),
+		missing:     make(map[string]bool),
+	}
+	if overrideDir != "" {
+		m.override = os.DirFS(overrideDir)
+	}
+	return m
+}
+
+// Open abre un asset buscando primero en el override
+func (m *AssetManager) Open(name string) (fs.File, error) {
+	if m.override != nil {
+		f, err := m.override.Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("asset %s in %s: %w", name, m.overrideDir, err)
+		}
+	}
+	return m.base.Open(name)
+}
+
+// load decodifica una imagen sin pasar por la caché
+func (m *AssetManager) load(name string) (*ebiten.Image, error) {
+	if m.override != nil {
+		img, _, err := ebitenutil.NewImageFromFileSystem(m.override, name)
+		if err == nil {
+			return img, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("asset %s in %s: %w", name, m.overrideDir, err)
+		}
+	}
+	img, _, err := ebitenutil.NewImageFromFileSystem(m.base, name)
+	if err != nil {
+		return nil, fmt.Errorf("asset %s: %w", name, err)
+	}
+	return img, nil
+}
+
+// Lookup retorna la imagen cacheada o la carga. ok es false si el archivo
+// no existe o no se pudo decodificar (el error se reporta una sola vez).
+func (m *AssetManager) Lookup(name string) (img *ebiten.Image, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if img, ok := m.cache[name]; ok {
+		return img, true
+	}
+	if m.missing[name] {
+		return nil, false
+	}
+
+	img, err := m.load(name)
+	if err != nil {
+		m.missing[name] = true
+		fmt.Println("Warning: failed to load", name+":", err)
+		return nil, false
+	}
+	m.cache[name] = img
+	return img, true
+}
+
+// Image retorna la imagen pedida o un placeholder si no se pudo cargar,
+// de modo que un sprite faltante nunca impide iniciar el juego
+func (m *AssetManager) Image(name string) *ebiten.Image {
+	if img, ok := m.Lookup(name); ok {
+		return img
+	}
+	return placeholderImage(name)
+}
+
+// Version retorna el contador de cambios del directorio de assets
+func (m *AssetManager) Version() int64 {
+	return m.version.Load()
+}
+
+// invalidate descarta de la caché los assets modificados y avisa del cambio
+func (m *AssetManager) invalidate(names []string) {
+	m.mu.Lock()
+	for _, name := range names {
+		delete(m.cache, name)
+		delete(m.missing, name)
+	}
+	m.mu.Unlock()
+	m.version.Add(1)
+}
+
+// Watch revisa periódicamente el directorio de override y descarta de la
+// caché los archivos que cambiaron, para recargarlos en caliente.
+// Termina cuando se cancela ctx. Sin directorio de override no hace nada.
+func (m *AssetManager) Watch(ctx context.Context) {
+	if m.overrideDir == "" {
+		return
+	}
+
+	ticker := time.NewTicker(assetPollInterval)
+	defer ticker.Stop()
+
+	known := m.scan()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			current := m.scan()
+			var changed []string
+			for name, stamp := range current {
+				if known[name] != stamp {
+					changed = append(changed, name)
+				}
+			}
+			for name := range known {
+				if _, ok := current[name]; !ok {
+					changed = append(changed, name)
+				}
+			}
+			known = current
+
+			if len(changed) > 0 {
+				fmt.Println("Assets changed, reloading:", changed)
+				m.invalidate(changed)
+			}
+		}
+	}
+}
+
+// fileStamp identifica una versión de un archivo
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// scan lista los archivos del directorio de override con su fecha y tamaño
+func (m *AssetManager) scan() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	entries, err := os.ReadDir(m.overrideDir)
+	if err != nil {
+		return stamps
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		stamps[entry.Name()] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps
+}
+
+// Placeholders compartidos por nombre de asset
+var (
+	placeholders   = make(map[string]*ebiten.Image)
+	placeholdersMu sync.Mutex
+)
+
+// placeholderImage crea (una sola vez por asset) un tablero magenta y negro
+// del tamaño esperado, fácil de reconocer en pantalla
+func placeholderImage(name string) *ebiten.Image {
+	placeholdersMu.Lock()
+	defer placeholdersMu.Unlock()
+
+	if img, ok := placeholders[name]; ok {
+		return img
+	}
+
+	size, ok := placeholderSizes[name]
+	if !ok {
+		size = image.Pt(32, 32)
+	}
+	pix := make([]byte, 4*size.X*size.Y)
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			i := 4 * (y*size.X + x)
+			if (x/4+y/4)%2 == 0 {
+				pix[i], pix[i+1], pix[i+2] = 255, 0, 255
+			}
+			pix[i+3] = 255
+		}
+	}
+	img := ebiten.NewImage(size.X, size.Y)
+	img.WritePixels(pix)
+	placeholders[name] = img
+	return img
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"image"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tamaño de cada página del atlas y separación entre sprites
+// (la separación evita que al escalar se mezclen píxeles del vecino)
+const (
+	atlasPageSize = 1024
+	atlasPadding  = 1
+)
+
+// Atlas agrupa los sprites en pocas imágenes grandes (páginas), de modo que
+// dibujar la escena cambia de textura lo menos posible. Cada sprite se
+// obtiene por su nombre de archivo como una sub-imagen de su página.
+// Un Atlas no cambia después de construirlo; la recarga crea uno nuevo.
+type Atlas struct {
+	pages   []*ebiten.Image
+	regions map[string]*ebiten.Image
+}
+
+// atlasShelf es una fila de sprites dentro de una página
+type atlasShelf struct {
+	x, y   int // Próxima posición libre
+	height int // Alto de la fila (el del sprite más alto)
+}
+
+// BuildAtlas empaqueta las imágenes en páginas por estantes (shelf packing):
+// se ordenan de mayor a menor alto y se colocan de izquierda a derecha,
+// abriendo una fila nueva cuando no caben y una página nueva cuando se llena.
+// Un sprite más grande que una página ocupa una página propia.
+func BuildAtlas(images map[string]*ebiten.Image) *Atlas {
+	names := make([]string, 0, len(images))
+	for name := range images {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		hi, hj := images[names[i]].Bounds().Dy(), images[names[j]].Bounds().Dy()
+		if hi != hj {
+			return hi > hj
+		}
+		return names[i] < names[j]
+	})
+
+	a := &Atlas{regions: make(map[string]*ebiten.Image, len(images))}
+	var page *ebiten.Image
+	var shelf atlasShelf
+
+	for _, name := range names {
+		img := images[name]
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+		pw, ph := w+atlasPadding, h+atlasPadding
+
+		if pw > atlasPageSize || ph > atlasPageSize {
+			own := ebiten.NewImage(w, h)
+			copyInto(own, img, 0, 0)
+			a.pages = append(a.pages, own)
+			a.regions[name] = own
+			continue
+		}
+
+		// Fila nueva si no cabe a lo ancho, página nueva si no cabe a lo alto
+		if page != nil && shelf.x+pw > atlasPageSize {
+			shelf = atlasShelf{y: shelf.y + shelf.height}
+		}
+		if page == nil || shelf.y+ph > atlasPageSize {
+			page = ebiten.NewImage(atlasPageSize, atlasPageSize)
+			a.pages = append(a.pages, page)
+			shelf = atlasShelf{}
+		}
+		if ph > shelf.height {
+			shelf.height = ph
+		}
+
+		copyInto(page, img, shelf.x, shelf.y)
+		a.regions[name] = page.SubImage(image.Rect(shelf.x, shelf.y, shelf.x+w, shelf.y+h)).(*ebiten.Image)
+		shelf.x += pw
+	}
+	return a
+}
+
+// copyInto copia src en dst en la posición (x, y) sin mezclar transparencias
+func copyInto(dst, src *ebiten.Image, x, y int) {
+	op := &ebiten.DrawImageOptions{}
+	op.Blend = ebiten.BlendCopy
+	op.GeoM.Translate(float64(x), float64(y))
+	dst.DrawImage(src, op)
+}
+
+// Image retorna el sprite empaquetado con ese nombre
+func (a *Atlas) Image(name string) (*ebiten.Image, bool) {
+	if a == nil {
+		return nil, false
+	}
+	img, ok := a.regions[name]
+	return img, ok
+}
+
+// Pages retorna la cantidad de páginas del atlas
+func (a *Atlas) Pages() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.pages)
+}
+
+// deallocate libera las páginas de un atlas que ya no se usa
+// IMPORTANTE: sólo desde la goroutine de Update/Draw, cuando nadie dibuja con él
+func (a *Atlas) deallocate() {
+	if a == nil {
+		return
+	}
+	for _, page := range a.pages {
+		page.Deallocate()
+	}
+}
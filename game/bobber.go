@@ -1,11 +1,9 @@
 package game
 
 import (
-	"image"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 type BobberState int
@@ -16,31 +14,47 @@ const (
 	BobberCaught
 )
 
+// Animaciones del bobber por estado
+var bobberAnimations = [...]string{
+	BobberFloating: "bobber_floating",
+	BobberBite:     "bobber_bite",
+	BobberCaught:   "bobber_caught",
+}
+
+// Parámetros del vuelo del lanzamiento
+const (
+	bobberFlightSpeed    = 3.0 // Píxeles por tick sobre el agua
+	bobberMinFlightTicks = 15  // Duración mínima del vuelo
+	bobberArcHeight      = 0.4 // Altura máxima como fracción de la distancia
+)
+
 type Bobber struct {
 	X, Y     float64
-	active   bool
+	active   bool // En el agua y listo para picadas
 	state    BobberState
 	bobCount int
-	sprite   *ebiten.Image
+	anim     AnimationPlayer
+
+	// Vuelo del lanzamiento: X, Y es la proyección sobre el agua
+	// y height la altura sobre ella
+	flying                bool
+	fromX, fromY          float64
+	toX, toY              float64
+	flightTick, flightLen int
+	height, peak          float64
 }
 
 func NewBobber() *Bobber {
 	return &Bobber{
 		active: false,
 		state:  BobberFloating,
+		anim:   AnimationPlayer{name: bobberAnimations[BobberFloating]},
 	}
 }
 
-// LoadSprites carga el sprite del bobber
-func (b *Bobber) LoadSprites() error {
-	var err error
-	b.sprite, _, err = ebitenutil.NewImageFromFile("assets/bobber.png")
-	return err
-}
-
-// Cast lanza el anzuelo desde la posición del jugador
-func (b *Bobber) Cast(playerX, playerY float64) {
-	// Calcular posición en el agua (hacia el centro del lago)
+// castTarget calcula dónde cae el anzuelo: a castDistance de la posición
+// del jugador, hacia el centro del lago
+func castTarget(playerX, playerY, castDistance float64) (float64, float64) {
 	dx := float64(LakeCenterX) - playerX
 	dy := float64(LakeCenterY) - playerY
 	distance := math.Sqrt(dx*dx + dy*dy)
@@ -51,73 +65,131 @@ func (b *Bobber) Cast(playerX, playerY float64) {
 	}
 
 	// Normalizar y lanzar a cierta distancia
-	castDistance := 70.0
-	b.X = playerX + (dx/distance)*castDistance
-	b.Y = playerY + (dy/distance)*castDistance
+	return playerX + (dx/distance)*castDistance, playerY + (dy/distance)*castDistance
+}
 
-	b.active = true
-	b.state = BobberFloating
+// clampToLake acerca un punto al centro del lago si queda fuera del margen
+// que también respeta Drift (LakeRadius-10)
+func clampToLake(x, y float64) (float64, float64) {
+	dx, dy := x-LakeCenterX, y-LakeCenterY
+	distance := math.Hypot(dx, dy)
+	if distance <= LakeRadius-10 {
+		return x, y
+	}
+	scale := (LakeRadius - 10) / distance
+	return LakeCenterX + dx*scale, LakeCenterY + dy*scale
+}
+
+// Cast lanza el anzuelo desde (fromX, fromY) (la punta de la caña) en un
+// vuelo parabólico hasta (toX, toY). Sólo acepta picadas después de caer.
+func (b *Bobber) Cast(fromX, fromY, toX, toY float64) {
+	b.X, b.Y = fromX, fromY
+	b.fromX, b.fromY = fromX, fromY
+	b.toX, b.toY = toX, toY
+
+	distance := math.Hypot(toX-fromX, toY-fromY)
+	b.flightLen = max(bobberMinFlightTicks, int(distance/bobberFlightSpeed))
+	b.flightTick = 0
+	b.peak = distance * bobberArcHeight
+	b.height = 0
+	b.flying = true
+
+	b.active = false
+	b.SetState(BobberFloating)
 	b.bobCount = 0
 }
 
-// Update actualiza el bobber (animación de flotar)
-func (b *Bobber) Update() {
-	if !b.active {
-		return
+// Update actualiza el bobber: vuelo y animación de flotar.
+// Retorna true en el tick en que el bobber cae al agua.
+func (b *Bobber) Update() (landed bool) {
+	if b.flying {
+		return b.updateFlight()
+	}
+	if !b.Visible() {
+		return false
 	}
 	b.bobCount++
+	b.anim.Update()
+	return false
 }
 
-// Draw dibuja el bobber
-func (b *Bobber) Draw(screen *ebiten.Image) {
-	if !b.active {
-		return
-	}
-
-	if b.sprite == nil {
-		return
+// updateFlight avanza el vuelo y posa el bobber en el agua al terminar
+func (b *Bobber) updateFlight() (landed bool) {
+	b.flightTick++
+	t := float64(b.flightTick) / float64(b.flightLen)
+	if t >= 1 {
+		b.X, b.Y = b.toX, b.toY
+		b.height = 0
+		b.flying = false
+		b.active = true
+		return true
 	}
 
-	// Seleccionar frame según el estado
-	frameWidth := 32
-	frameHeight := 32
-
-	sx := int(b.state) * frameWidth
-	sy := 0
+	b.X = b.fromX + (b.toX-b.fromX)*t
+	b.Y = b.fromY + (b.toY-b.fromY)*t
+	b.height = 4 * b.peak * t * (1 - t)
+	return false
+}
 
-	op := &ebiten.DrawImageOptions{}
+// Drift desplaza el bobber que flota (por ejemplo con el viento),
+// manteniéndolo dentro del lago
+func (b *Bobber) Drift(dx, dy float64) {
+	x, y := b.X+dx, b.Y+dy
+	if math.Hypot(x-LakeCenterX, y-LakeCenterY) < LakeRadius-10 {
+		b.X, b.Y = x, y
+	}
+}
 
-	// Efecto de bobbing (movimiento vertical)
-	bobOffset := math.Sin(float64(b.bobCount)*0.12) * 2.5
+// Visible indica si el bobber se dibuja: en vuelo, esperando una picada,
+// peleando con un legendario o mostrando la captura (ya inactivo para colisiones)
+func (b *Bobber) Visible() bool {
+	return b.active || b.flying || b.state != BobberFloating
+}
 
-	op.GeoM.Translate(-float64(frameWidth)/2, -float64(frameHeight)/2)
-	op.GeoM.Translate(b.X, b.Y+bobOffset)
+// bobOffset es el desplazamiento vertical del efecto de flotar
+func (b *Bobber) bobOffset() float64 {
+	return math.Sin(float64(b.bobCount)*0.12) * 2.5
+}
 
-	subImg := b.sprite.SubImage(image.Rect(sx, sy, sx+frameWidth, sy+frameHeight)).(*ebiten.Image)
-	screen.DrawImage(subImg, op)
+// drawY retorna la posición vertical en pantalla (altura de vuelo y bobbing)
+func (b *Bobber) drawY() float64 {
+	return b.Y - b.height + b.bobOffset()
+}
 
-	// Dibujar línea desde el bobber hacia arriba (simulando la línea de pesca)
-	drawFishingLine(screen, b.X, b.Y+bobOffset-20)
+// LineAnchor retorna el punto donde se ata la línea de pesca
+func (b *Bobber) LineAnchor() (float64, float64) {
+	x, y, _ := b.anim.Anchor("line", b.X, b.drawY())
+	return x, y
 }
 
-// drawFishingLine dibuja una línea simple hacia arriba
-func drawFishingLine(screen *ebiten.Image, x, y float64) {
-	lineImg := ebiten.NewImage(2, 30)
-	lineImg.Fill(image.White.C)
+// Draw dibuja el bobber y su sombra mientras vuela
+func (b *Bobber) Draw(screen *ebiten.Image) {
+	if !b.Visible() {
+		return
+	}
 
-	op := &ebiten.DrawImageOptions{}
-	op.ColorScale.Scale(0.5, 0.5, 0.5, 0.8) // Gris semi-transparente
-	op.GeoM.Translate(x-1, y-30)
+	if b.flying {
+		// Sombra sobre el agua: más chica y tenue cuanto más alto vuela
+		scale := 1 - 0.5*b.height/math.Max(b.peak, 1)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale*0.5)
+		op.ColorScale.Scale(0, 0, 0, float32(0.4*scale))
+		b.anim.DrawAt(screen, b.X, b.Y, op)
+	}
 
-	screen.DrawImage(lineImg, op)
+	// Efecto de bobbing (movimiento vertical)
+	b.anim.DrawAt(screen, b.X, b.drawY(), nil)
 }
 
 func (b *Bobber) SetState(state BobberState) {
 	b.state = state
+	b.anim.Play(bobberAnimations[state])
 }
 
 func (b *Bobber) Reset() {
 	b.active = false
-	b.state = BobberFloating
+	b.flying = false
+	b.height = 0
+	b.SetState(BobberFloating)
 	b.bobCount = 0
 }
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Minutos de un día de juego y hora a la que empieza una partida nueva
+const (
+	minutesPerDay = 24 * 60
+	startMinutes  = 7 * 60
+)
+
+// DayPhase es la fase del día del reloj del juego
+type DayPhase int
+
+const (
+	PhaseDawn  DayPhase = iota // 05:00 - 07:00
+	PhaseDay                   // 07:00 - 18:00
+	PhaseDusk                  // 18:00 - 20:00
+	PhaseNight                 // 20:00 - 05:00
+	numPhases
+)
+
+// String retorna el nombre de la fase (se muestra en el HUD)
+func (p DayPhase) String() string {
+	switch p {
+	case PhaseDawn:
+		return "Amanecer"
+	case PhaseDay:
+		return "Día"
+	case PhaseDusk:
+		return "Atardecer"
+	case PhaseNight:
+		return "Noche"
+	default:
+		return "?"
+	}
+}
+
+// phaseAt retorna la fase correspondiente a un minuto del día
+func phaseAt(minutes float64) DayPhase {
+	switch hour := minutes / 60; {
+	case hour >= 5 && hour < 7:
+		return PhaseDawn
+	case hour >= 7 && hour < 18:
+		return PhaseDay
+	case hour >= 18 && hour < 20:
+		return PhaseDusk
+	default:
+		return PhaseNight
+	}
+}
+
+// Actividad de cada especie por fase: multiplica su probabilidad de aparecer
+// (sobre las probabilidades base 60/25/12/3) y su velocidad de nado.
+// Los legendarios sólo salen de noche.
+var phaseSpawnWeights = [numPhases][numFishTypes]float64{
+	PhaseDawn:  {1.2, 1.3, 1.0, 0},
+	PhaseDay:   {1.0, 1.0, 0.8, 0},
+	PhaseDusk:  {1.2, 1.3, 1.3, 0},
+	PhaseNight: {0.6, 0.8, 1.2, 2.0},
+}
+
+var phaseSwimSpeeds = [numPhases][numFishTypes]float64{
+	PhaseDawn:  {1.1, 1.1, 1.0, 1.0},
+	PhaseDay:   {1.0, 1.0, 0.9, 1.0},
+	PhaseDusk:  {1.1, 1.2, 1.2, 1.0},
+	PhaseNight: {0.7, 0.8, 1.0, 1.3},
+}
+
+// Tinte del lago a lo largo del día: (hora, r, g, b), interpolado linealmente
+var clockTints = [...][4]float32{
+	{0, 0.35, 0.40, 0.65},
+	{5, 0.45, 0.45, 0.70},
+	{6, 0.95, 0.75, 0.70},
+	{8, 1, 1, 1},
+	{17, 1, 1, 1},
+	{19, 1, 0.75, 0.60},
+	{21, 0.35, 0.40, 0.65},
+	{24, 0.35, 0.40, 0.65},
+}
+
+// GameClock es el reloj del juego. Un día completo dura dayLength de tiempo
+// real y el reloj avanza un tick por cada Step, así la simulación headless
+// es determinista. Sólo la goroutine de Update lo modifica (con g.mu tomado).
+type GameClock struct {
+	minutes float64 // Minuto del día, de 0 a minutesPerDay
+	day     int     // Días completos transcurridos
+	rate    float64 // Minutos de juego por tick
+}
+
+// NewGameClock crea un reloj en el día y minuto dados
+func NewGameClock(dayLength time.Duration, day int, minutes float64) GameClock {
+	ticks := dayLength.Seconds() * ebiten.DefaultTPS
+	return GameClock{
+		minutes: minutes,
+		day:     day,
+		rate:    minutesPerDay / ticks,
+	}
+}
+
+// Tick avanza el reloj un tick. Retorna true si cambió la fase del día o
+// empezó un día nuevo (que puede traer otra estación).
+func (c *GameClock) Tick() bool {
+	prev := c.Phase()
+	c.minutes += c.rate
+	if c.minutes >= minutesPerDay {
+		c.minutes -= minutesPerDay
+		c.day++
+		return true
+	}
+	return c.Phase() != prev
+}
+
+// Phase retorna la fase actual
+func (c *GameClock) Phase() DayPhase {
+	return phaseAt(c.minutes)
+}
+
+// Day retorna los días completos transcurridos
+func (c *GameClock) Day() int {
+	return c.day
+}
+
+// Minutes retorna el minuto del día
+func (c *GameClock) Minutes() float64 {
+	return c.minutes
+}
+
+// HourMinute retorna la hora actual redondeada al minuto
+func (c *GameClock) HourMinute() (int, int) {
+	m := int(c.minutes)
+	return m / 60, m % 60
+}
+
+// Tint retorna el color con que se tiñe el lago a la hora actual
+func (c *GameClock) Tint() (r, g, b float32) {
+	hour := float32(c.minutes / 60)
+	for i := 1; i < len(clockTints); i++ {
+		next := clockTints[i]
+		if hour > next[0] {
+			continue
+		}
+		prev := clockTints[i-1]
+		t := (hour - prev[0]) / (next[0] - prev[0])
+		return prev[1] + (next[1]-prev[1])*t,
+			prev[2] + (next[2]-prev[2])*t,
+			prev[3] + (next[3]-prev[3])*t
+	}
+	last := clockTints[len(clockTints)-1]
+	return last[1], last[2], last[3]
+}
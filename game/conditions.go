@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// atomicFloat es un float64 que se puede leer y escribir desde varias goroutines
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat) Store(v float64) {
+	f.bits.Store(math.Float64bits(v))
+}
+
+// Conditions son las condiciones actuales del lago que afectan a los peces.
+// Las escribe sólo la goroutine de Update (al cambiar la fase del día, el día
+// o el clima) y las leen sin bloquear el spawner y las goroutines de los peces.
+type Conditions struct {
+	spawnWeight [numFishTypes]atomicFloat // Peso de cada especie al sortear el tipo
+	swimSpeed   [numFishTypes]atomicFloat // Multiplicador de la velocidad de nado
+	capScale    [numFishTypes]atomicFloat // Multiplicador del límite de peces
+	stock       [numFishTypes]atomicFloat // Abundancia de la especie (ver ecology.go)
+}
+
+// NewConditions crea condiciones neutras (probabilidades base, velocidad y límites normales)
+func NewConditions() *Conditions {
+	c := &Conditions{}
+	for t := FishCommon; t < numFishTypes; t++ {
+		c.spawnWeight[t].Store(baseSpawnWeights[t])
+		c.swimSpeed[t].Store(1)
+		c.capScale[t].Store(1)
+		c.stock[t].Store(1)
+	}
+	return c
+}
+
+// SpawnWeight retorna el peso actual de una especie al sortear el tipo de
+// pez, escalado por su abundancia
+func (c *Conditions) SpawnWeight(t FishType) float64 {
+	return c.spawnWeight[t].Load() * c.stock[t].Load()
+}
+
+// SwimSpeed retorna el multiplicador actual de velocidad de nado de una especie
+func (c *Conditions) SwimSpeed(t FishType) float64 {
+	return c.swimSpeed[t].Load()
+}
+
+// MaxFish retorna el límite actual de una especie a partir del configurado,
+// ajustado a la estación y a su abundancia
+func (c *Conditions) MaxFish(t FishType, base int) int {
+	return int(math.Round(float64(base) * c.capScale[t].Load() * c.stock[t].Load()))
+}
+
+// Active indica si la especie puede aparecer en las condiciones actuales
+func (c *Conditions) Active(t FishType) bool {
+	return c.SpawnWeight(t) > 0
+}
+
+// setStock actualiza la abundancia de una especie
+func (c *Conditions) setStock(t FishType, stock float64) {
+	c.stock[t].Store(stock)
+}
+
+// apply ajusta las condiciones a la hora y estación del reloj y al clima
+func (c *Conditions) apply(clock *GameClock, weather WeatherKind) {
+	phase, season := clock.Phase(), clock.Season()
+	for t := FishCommon; t < numFishTypes; t++ {
+		weight := baseSpawnWeights[t] * phaseSpawnWeights[phase][t] * weatherSpawnWeights[weather][t] * seasonSpawnWeights[season][t]
+		if t == FishLegendary && clock.Migration() {
+			weight *= migrationSpawnWeight
+		}
+		c.spawnWeight[t].Store(weight)
+		c.swimSpeed[t].Store(phaseSwimSpeeds[phase][t] * weatherSwimSpeeds[weather][t])
+		c.capScale[t].Store(seasonCapScales[season][t])
+	}
+}
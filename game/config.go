@@ -0,0 +1,245 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Dificultades disponibles
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+)
+
+// Duration es un time.Duration que se lee y escribe en JSON como texto ("3s", "500ms")
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON escribe la duración como texto
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON lee la duración desde texto
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be a string like \"3s\": %w", err)
+	}
+	v, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// Config son los parámetros del juego. Se leen de un archivo JSON y
+// las opciones de línea de comandos tienen prioridad sobre el archivo.
+type Config struct {
+	// Sesión
+	Seed       int64  `json:"seed"`       // 0 = semilla aleatoria
+	Difficulty string `json:"difficulty"` // easy, normal, hard
+
+	// Ventana
+	WindowTitle  string `json:"window_title"`
+	WindowWidth  int    `json:"window_width"`
+	WindowHeight int    `json:"window_height"`
+	Fullscreen   bool   `json:"fullscreen"`
+	VSync        bool   `json:"vsync"`
+
+	// Assets embebidos en el binario (los asigna main, no se lee del archivo)
+	// y directorio opcional en disco cuyos archivos los reemplazan (mods)
+	Assets    fs.FS  `json:"-"`
+	AssetDir  string `json:"asset_dir"`
+	HotReload bool   `json:"hot_reload"` // Recargar sprites al cambiar AssetDir
+
+	// Directorio de partidas guardadas
+	SaveDir string `json:"save_dir"`
+
+	// Jugabilidad
+	SpawnInterval    Duration    `json:"spawn_interval"` // Ritmo medio de aparición con el lago vacío
+	SpawnZones       []SpawnZone `json:"spawn_zones"`    // Vacío = todo el lago
+	MaxCommonFish    int         `json:"max_common_fish"`
+	MaxRareFish      int         `json:"max_rare_fish"`
+	MaxEpicFish      int         `json:"max_epic_fish"`
+	MaxLegendaryFish int         `json:"max_legendary_fish"`
+	CastDistance     float64     `json:"cast_distance"`
+	CatchRadius      float64     `json:"catch_radius"`
+	CatchResetDelay  Duration    `json:"catch_reset_delay"`
+
+	// Duración en tiempo real de un día completo del reloj del juego
+	DayLength Duration `json:"day_length"`
+}
+
+// DefaultConfig retorna la configuración por defecto
+func DefaultConfig() Config {
+	return Config{
+		Difficulty: DifficultyNormal,
+
+		WindowTitle:  "Fishing Game - Concurrent Programming",
+		WindowWidth:  ScreenWidth,
+		WindowHeight: ScreenHeight,
+		VSync:        true,
+
+		SaveDir: ".",
+
+		SpawnInterval:    Duration{3 * time.Second},
+		MaxCommonFish:    MaxCommonFish,
+		MaxRareFish:      MaxRareFish,
+		MaxEpicFish:      MaxEpicFish,
+		MaxLegendaryFish: MaxLegendaryFish,
+		CastDistance:     70,
+		CatchRadius:      15,
+		CatchResetDelay:  Duration{1 * time.Second},
+
+		DayLength: Duration{10 * time.Minute},
+	}
+}
+
+// LoadConfig lee un archivo de configuración sobre los valores por defecto.
+// Los campos desconocidos se reportan como error para detectar errores de tipeo.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("config: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate verifica que todos los valores sean válidos y reporta todos los errores juntos
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	switch c.Difficulty {
+	case DifficultyEasy, DifficultyNormal, DifficultyHard:
+	default:
+		errs = append(errs, fmt.Errorf("difficulty %q: must be %s, %s or %s",
+			c.Difficulty, DifficultyEasy, DifficultyNormal, DifficultyHard))
+	}
+
+	check(c.WindowWidth >= 160 && c.WindowHeight >= 120,
+		"window size %dx%d: must be at least 160x120", c.WindowWidth, c.WindowHeight)
+
+	// asset_dir es opcional: vacío significa usar sólo los assets embebidos
+	if c.AssetDir != "" {
+		if info, err := os.Stat(c.AssetDir); err != nil {
+			errs = append(errs, fmt.Errorf("asset_dir %q: %w", c.AssetDir, err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("asset_dir %q: not a directory", c.AssetDir))
+		}
+	}
+	check(!c.HotReload || c.AssetDir != "", "hot_reload: requires asset_dir")
+	check(c.SaveDir != "", "save_dir: must not be empty")
+
+	check(c.SpawnInterval.Duration >= 100*time.Millisecond,
+		"spawn_interval %v: must be at least 100ms", c.SpawnInterval.Duration)
+	for i, zone := range c.SpawnZones {
+		if err := zone.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("spawn_zones[%d]: %w", i, err))
+		}
+	}
+	check(c.MaxCommonFish >= 0 && c.MaxRareFish >= 0 && c.MaxEpicFish >= 0 && c.MaxLegendaryFish >= 0,
+		"max fish caps must not be negative")
+	check(c.MaxCommonFish+c.MaxRareFish+c.MaxEpicFish+c.MaxLegendaryFish > 0,
+		"max fish caps: at least one species must be allowed")
+	check(c.CastDistance > 0 && c.CastDistance < LakeRadius*2,
+		"cast_distance %.1f: must be between 0 and %d", c.CastDistance, LakeRadius*2)
+	check(c.CatchRadius > 0 && c.CatchRadius <= 100,
+		"catch_radius %.1f: must be between 0 and 100", c.CatchRadius)
+	check(c.CatchResetDelay.Duration >= 0,
+		"catch_reset_delay %v: must not be negative", c.CatchResetDelay.Duration)
+	check(c.DayLength.Duration >= 10*time.Second,
+		"day_length %v: must be at least 10s", c.DayLength.Duration)
+
+	return errors.Join(errs...)
+}
+
+// withDifficulty retorna la configuración con los ajustes de la dificultad aplicados
+func (c Config) withDifficulty() Config {
+	switch c.Difficulty {
+	case DifficultyEasy:
+		c.CatchRadius *= 1.3
+		c.SpawnInterval.Duration = c.SpawnInterval.Duration * 3 / 4
+	case DifficultyHard:
+		c.CatchRadius *= 0.75
+		c.SpawnInterval.Duration = c.SpawnInterval.Duration * 5 / 4
+	}
+	return c
+}
+
+// maxFish retorna el límite de peces configurado para un tipo
+func (c Config) maxFish(fishType FishType) int {
+	switch fishType {
+	case FishCommon:
+		return c.MaxCommonFish
+	case FishRare:
+		return c.MaxRareFish
+	case FishEpic:
+		return c.MaxEpicFish
+	case FishLegendary:
+		return c.MaxLegendaryFish
+	default:
+		return 0
+	}
+}
+
+// savePath retorna la ruta de un archivo de guardado dentro del directorio configurado
+func (c Config) savePath(name string) string {
+	return filepath.Join(c.SaveDir, name)
+}
+
+// lockedSource es una fuente aleatoria segura para usar desde varias goroutines
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// newRand crea el generador aleatorio del juego. Con seed 0 usa la hora actual.
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
@@ -0,0 +1,209 @@
+package game
+
+import (
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Modelo de ecología: cada especie tiene una abundancia (1 = población sana)
+// que escala su probabilidad de aparecer y su límite de peces en el lago.
+// Las capturas y la predación la reducen y se recupera sola con un
+// crecimiento logístico, así el lago responde a cómo se pesca.
+const (
+	ecologyCarrying       = 1.25  // Abundancia máxima de una especie que se deja en paz
+	ecologyGrowthRate     = 0.015 // Crecimiento logístico por segundo (reproducción)
+	ecologyMinStock       = 0.05  // Nunca se extingue del todo (llegan peces río arriba)
+	ecologyCatchDepletion = 0.06  // Abundancia que resta cada captura
+	ecologyEatenDepletion = 0.02  // Abundancia que resta cada pez comido
+
+	predationInterval = 30   // Ticks entre chequeos de predación
+	predationRadius   = 14.0 // Distancia a la que un pez grande alcanza a uno chico
+	predationChance   = 0.25 // Probabilidad de que lo coma en cada chequeo
+
+	historyInterval = 5 * ebiten.DefaultTPS // Ticks entre muestras del historial
+	historyLen      = 120                   // Muestras guardadas (10 minutos)
+)
+
+// Colores de cada especie en el gráfico de población
+var colorSpecies = [numFishTypes]color.RGBA{
+	FishCommon:    {150, 200, 150, 255},
+	FishRare:      {90, 150, 255, 255},
+	FishEpic:      {200, 100, 230, 255},
+	FishLegendary: {255, 200, 60, 255},
+}
+
+// Nombres de las especies en la leyenda del gráfico
+var speciesLabels = [numFishTypes]string{
+	FishCommon:    "Comunes",
+	FishRare:      "Raros",
+	FishEpic:      "Épicos",
+	FishLegendary: "Legendarios",
+}
+
+// PopulationSample es una muestra del historial de población
+type PopulationSample struct {
+	Stock  [numFishTypes]float64 // Abundancia de cada especie
+	InLake [numFishTypes]int     // Peces de cada especie en el lago
+}
+
+// Ecology lleva la abundancia de cada especie y su historial.
+// Sólo la goroutine de Update la modifica (con g.mu tomado).
+type Ecology struct {
+	stock    [numFishTypes]float64
+	ticks    int
+	history  [historyLen]PopulationSample // Buffer circular
+	historyN int
+	rng      *rand.Rand
+}
+
+// NewEcology crea un lago con todas las especies sanas.
+// rng se usa para derivar el generador propio de la predación.
+func NewEcology(rng *rand.Rand) *Ecology {
+	e := &Ecology{rng: rand.New(rand.NewSource(rng.Int63()))}
+	for t := FishCommon; t < numFishTypes; t++ {
+		e.stock[t] = 1
+	}
+	return e
+}
+
+// Stock retorna la abundancia actual de una especie
+func (e *Ecology) Stock(t FishType) float64 {
+	return e.stock[t]
+}
+
+// SetStock restaura la abundancia de una especie (al cargar una partida)
+func (e *Ecology) SetStock(t FishType, stock float64) {
+	e.stock[t] = min(max(stock, ecologyMinStock), ecologyCarrying)
+}
+
+// Caught descuenta un pez capturado
+func (e *Ecology) Caught(t FishType) {
+	e.SetStock(t, e.stock[t]-ecologyCatchDepletion)
+}
+
+// Eaten descuenta un pez comido por otro
+func (e *Ecology) Eaten(t FishType) {
+	e.SetStock(t, e.stock[t]-ecologyEatenDepletion)
+}
+
+// Update hace crecer las especies un tick y guarda una muestra del
+// historial cada historyInterval ticks
+func (e *Ecology) Update(inLake [numFishTypes]int) {
+	rate := ecologyGrowthRate / ebiten.DefaultTPS
+	for t := FishCommon; t < numFishTypes; t++ {
+		s := e.stock[t]
+		e.SetStock(t, s+rate*s*(1-s/ecologyCarrying))
+	}
+
+	e.ticks++
+	if e.ticks%historyInterval == 0 {
+		e.history[e.historyN%historyLen] = PopulationSample{Stock: e.stock, InLake: inLake}
+		e.historyN++
+	}
+}
+
+// History agrega a dst las muestras guardadas, de la más antigua a la más reciente
+func (e *Ecology) History(dst []PopulationSample) []PopulationSample {
+	n := min(e.historyN, historyLen)
+	for i := e.historyN - n; i < e.historyN; i++ {
+		dst = append(dst, e.history[i%historyLen])
+	}
+	return dst
+}
+
+// updateEcology avanza el modelo de ecología: crecimiento, predación y
+// la abundancia que usan el spawner y los límites de peces
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) updateEcology() {
+	if g.frameCount%predationInterval == 0 {
+		g.updatePredation()
+	}
+
+	var inLake [numFishTypes]int
+	for _, fish := range g.fishes {
+		inLake[fish.FishType]++
+	}
+	g.ecology.Update(inLake)
+	for t := FishCommon; t < numFishTypes; t++ {
+		g.conditions.setStock(t, g.ecology.Stock(t))
+	}
+}
+
+// updatePredation deja que los peces grandes se coman a los de especies más
+// chicas que tengan cerca. Cada predador come a lo sumo un pez por chequeo.
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) updatePredation() {
+	for i := 0; i < len(g.fishes); i++ {
+		predator := g.fishes[i]
+		if predator.FishType == FishCommon {
+			continue
+		}
+		px, py := predator.Position()
+
+		for j := 0; j < len(g.fishes); j++ {
+			prey := g.fishes[j]
+			if prey.FishType >= predator.FishType || !prey.CheckCollision(px, py, predationRadius) {
+				continue
+			}
+			// Los predadores agresivos cazan más seguido
+			if g.ecology.rng.Float64() >= predationChance*(0.5+predator.Traits.Aggression) {
+				continue
+			}
+
+			prey.Stop()
+			g.ecology.Eaten(prey.FishType)
+			g.bus.Publish(GameEvent{Type: EventFishEaten, FishType: prey.FishType})
+			g.fishes = append(g.fishes[:j], g.fishes[j+1:]...)
+			if j < i {
+				i--
+			}
+			break
+		}
+	}
+}
+
+// Gráfico de población (tecla G)
+const (
+	graphX, graphY = 120, 100
+	graphW, graphH = 400, 200
+)
+
+// drawPopulationGraph dibuja la abundancia de cada especie a lo largo del
+// historial. Copia las muestras a un buffer propio para no dibujar con g.mu tomado.
+func (g *Game) drawPopulationGraph(screen *ebiten.Image) {
+	if !g.graphVisible {
+		return
+	}
+	g.mu.Lock()
+	g.graphSamples = g.ecology.History(g.graphSamples[:0])
+	g.mu.Unlock()
+
+	fillRect(screen, graphX-10, graphY-30, graphW+20, graphH+60, colorOverlay)
+	ebitenutil.DebugPrintAt(screen, "Población del lago (G: cerrar)", graphX, graphY-24)
+	drawLine(screen, graphX, graphY+graphH, graphX+graphW, graphY+graphH, 1, colorLine)
+	drawLine(screen, graphX, graphY, graphX, graphY+graphH, 1, colorLine)
+
+	// Línea punteada en la abundancia sana (1)
+	healthyY := graphY + graphH*(1-1/ecologyCarrying)
+	for x := 0.0; x < graphW; x += 8 {
+		drawLine(screen, graphX+x, healthyY, graphX+x+4, healthyY, 1, colorLine)
+	}
+
+	samples := g.graphSamples
+	step := float64(graphW) / float64(historyLen-1)
+	for t := FishCommon; t < numFishTypes; t++ {
+		for i := 1; i < len(samples); i++ {
+			y1 := graphY + graphH*(1-samples[i-1].Stock[t]/ecologyCarrying)
+			y2 := graphY + graphH*(1-samples[i].Stock[t]/ecologyCarrying)
+			drawLine(screen, graphX+float64(i-1)*step, y1, graphX+float64(i)*step, y2, 2, colorSpecies[t])
+		}
+
+		// Leyenda
+		lx := graphX + int(t)*100
+		fillRect(screen, float64(lx), graphY+graphH+12, 8, 8, colorSpecies[t])
+		ebitenutil.DebugPrintAt(screen, speciesLabels[t], lx+12, graphY+graphH+8)
+	}
+}
@@ -0,0 +1,251 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// GameEventType identifica el tipo de evento publicado en el bus
+type GameEventType int
+
+const (
+	EventFishSpawned GameEventType = iota
+	EventFishCaught
+	EventFishEscaped
+	EventFishEaten
+	EventCast
+	EventReel
+	EventStateChanged
+
+	numEventTypes
+)
+
+// String retorna el nombre del tipo de evento (para métricas y depuración)
+func (t GameEventType) String() string {
+	switch t {
+	case EventFishSpawned:
+		return "FishSpawned"
+	case EventFishCaught:
+		return "FishCaught"
+	case EventFishEscaped:
+		return "FishEscaped"
+	case EventFishEaten:
+		return "FishEaten"
+	case EventCast:
+		return "Cast"
+	case EventReel:
+		return "Reel"
+	case EventStateChanged:
+		return "StateChanged"
+	default:
+		return "Unknown"
+	}
+}
+
+// GameEvent es un evento del juego. Según el tipo se usan distintos campos:
+//   - FishSpawned: Fish y FishType
+//   - FishCaught: FishType y Catch
+//   - FishEscaped, FishEaten: FishType
+//   - StateChanged: PrevState y State
+type GameEvent struct {
+	Type      GameEventType
+	FishType  FishType
+	Fish      *Fish
+	Catch     CatchRecord
+	PrevState GameState
+	State     GameState
+}
+
+// OverflowPolicy define qué hacer cuando la cola de un suscriptor está llena
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest descarta el evento que se intenta publicar
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest descarta el evento más antiguo de la cola para hacer espacio
+	OverflowDropOldest
+	// OverflowBlock espera hasta que haya espacio. Sólo descarta (y lo cuenta
+	// en Dropped) si el contexto del bus se cancela mientras espera; Shutdown
+	// cierra el bus antes de cancelar para que eso no pase con las capturas.
+	OverflowBlock
+)
+
+// Subscription es la cola acotada de un suscriptor del bus
+type Subscription struct {
+	name   string
+	kinds  [numEventTypes]bool
+	ch     chan GameEvent
+	policy OverflowPolicy
+
+	// Serializa el descarte del más antiguo entre publicadores concurrentes
+	dropMu sync.Mutex
+
+	delivered atomic.Int64
+	dropped   atomic.Int64
+}
+
+// C retorna el canal del que lee el suscriptor.
+// El canal se cierra cuando se cierra el bus.
+func (s *Subscription) C() <-chan GameEvent {
+	return s.ch
+}
+
+// SubscriptionStats son las métricas de un suscriptor
+type SubscriptionStats struct {
+	Name      string
+	Depth     int
+	Capacity  int
+	Delivered int64
+	Dropped   int64
+}
+
+// Stats retorna las métricas actuales del suscriptor
+func (s *Subscription) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		Name:      s.name,
+		Depth:     len(s.ch),
+		Capacity:  cap(s.ch),
+		Delivered: s.delivered.Load(),
+		Dropped:   s.dropped.Load(),
+	}
+}
+
+// EventBus es un bus pub/sub tipado con múltiples suscriptores.
+// Cada suscriptor tiene su propia cola acotada con una política explícita de desborde.
+type EventBus struct {
+	ctx       context.Context
+	mu        sync.RWMutex
+	subs      []*Subscription
+	closed    bool
+	published [numEventTypes]atomic.Int64
+}
+
+// NewEventBus crea un bus. El contexto desbloquea a los publicadores que esperan
+// con la política OverflowBlock cuando el juego se cierra.
+func NewEventBus(ctx context.Context) *EventBus {
+	return &EventBus{ctx: ctx}
+}
+
+// Subscribe registra un suscriptor para los tipos de evento indicados
+func (b *EventBus) Subscribe(name string, size int, policy OverflowPolicy, kinds ...GameEventType) *Subscription {
+	sub := &Subscription{
+		name:   name,
+		ch:     make(chan GameEvent, size),
+		policy: policy,
+	}
+	for _, kind := range kinds {
+		sub.kinds[kind] = true
+	}
+
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+	return sub
+}
+
+// Publish entrega el evento a todos los suscriptores interesados.
+// Después de Close el evento ya no se entrega: cuenta como descartado
+// para cada suscriptor interesado, con cualquier política.
+func (b *EventBus) Publish(ev GameEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		for _, sub := range b.subs {
+			if sub.kinds[ev.Type] {
+				sub.dropped.Add(1)
+			}
+		}
+		return
+	}
+	b.published[ev.Type].Add(1)
+
+	for _, sub := range b.subs {
+		if sub.kinds[ev.Type] {
+			b.deliver(sub, ev)
+		}
+	}
+}
+
+// deliver envía el evento a un suscriptor aplicando su política de desborde
+func (b *EventBus) deliver(sub *Subscription, ev GameEvent) {
+	switch sub.policy {
+	case OverflowBlock:
+		// Intentar primero sin bloquear para no perder eventos si el
+		// contexto ya se canceló pero aún queda espacio en la cola
+		select {
+		case sub.ch <- ev:
+			sub.delivered.Add(1)
+			return
+		default:
+		}
+		select {
+		case sub.ch <- ev:
+			sub.delivered.Add(1)
+		case <-b.ctx.Done():
+			sub.dropped.Add(1)
+		}
+
+	case OverflowDropOldest:
+		sub.dropMu.Lock()
+		defer sub.dropMu.Unlock()
+		for {
+			select {
+			case sub.ch <- ev:
+				sub.delivered.Add(1)
+				return
+			default:
+			}
+			// Cola llena: descartar el más antiguo y reintentar
+			select {
+			case <-sub.ch:
+				sub.dropped.Add(1)
+			default:
+			}
+		}
+
+	default: // OverflowDropNewest
+		select {
+		case sub.ch <- ev:
+			sub.delivered.Add(1)
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// Published retorna cuántos eventos de un tipo se han publicado
+func (b *EventBus) Published(kind GameEventType) int64 {
+	return b.published[kind].Load()
+}
+
+// Stats retorna las métricas de todos los suscriptores
+func (b *EventBus) Stats() []SubscriptionStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := make([]SubscriptionStats, 0, len(b.subs))
+	for _, sub := range b.subs {
+		stats = append(stats, sub.Stats())
+	}
+	return stats
+}
+
+// Close cierra el bus y los canales de todos los suscriptores.
+// Un publicador bloqueado con OverflowBlock retiene el bus, así que Close
+// espera hasta que el consumidor le haga lugar o se cancele el contexto.
+// Shutdown lo llama antes de cancelar (con catchProcessor todavía leyendo)
+// para no descartar capturas, y lo espera con límite de tiempo.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, sub := range b.subs {
+		close(sub.ch)
+	}
+}
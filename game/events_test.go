@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// subStats retorna las métricas de un suscriptor por nombre
+func subStats(t *testing.T, b *EventBus, name string) SubscriptionStats {
+	t.Helper()
+	for _, s := range b.Stats() {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("no subscription %q", name)
+	return SubscriptionStats{}
+}
+
+func TestBusDropPolicies(t *testing.T) {
+	b := NewEventBus(context.Background())
+	newest := b.Subscribe("newest", 2, OverflowDropNewest, EventCast)
+	oldest := b.Subscribe("oldest", 2, OverflowDropOldest, EventCast)
+	b.Subscribe("other", 2, OverflowDropNewest, EventReel)
+
+	for i := 0; i < 5; i++ {
+		b.Publish(GameEvent{Type: EventCast, FishType: FishType(i)})
+	}
+
+	if s := subStats(t, b, "newest"); s.Delivered != 2 || s.Dropped != 3 || s.Depth != 2 {
+		t.Errorf("newest stats = %+v, want 2 delivered, 3 dropped", s)
+	}
+	if s := subStats(t, b, "oldest"); s.Delivered != 5 || s.Dropped != 3 || s.Depth != 2 {
+		t.Errorf("oldest stats = %+v, want 5 delivered, 3 dropped", s)
+	}
+	if s := subStats(t, b, "other"); s.Delivered != 0 || s.Dropped != 0 {
+		t.Errorf("uninterested subscriber got events: %+v", s)
+	}
+
+	// DropNewest conserva los primeros, DropOldest los últimos
+	if ev := <-newest.C(); ev.FishType != 0 {
+		t.Errorf("newest kept event %d first, want 0", ev.FishType)
+	}
+	if ev := <-oldest.C(); ev.FishType != 3 {
+		t.Errorf("oldest kept event %d first, want 3", ev.FishType)
+	}
+	if got := b.Published(EventCast); got != 5 {
+		t.Errorf("Published(Cast) = %d, want 5", got)
+	}
+}
+
+// TestBusBlockDropsOnCancel cubre el único descarte de OverflowBlock:
+// el publicador espera con la cola llena y se cancela el contexto
+func TestBusBlockDropsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := NewEventBus(ctx)
+	b.Subscribe("block", 1, OverflowBlock, EventFishCaught)
+	b.Publish(GameEvent{Type: EventFishCaught})
+
+	done := make(chan struct{})
+	go func() {
+		b.Publish(GameEvent{Type: EventFishCaught})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Publish did not block on a full OverflowBlock queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	<-done
+
+	if s := subStats(t, b, "block"); s.Delivered != 1 || s.Dropped != 1 {
+		t.Errorf("block stats = %+v, want 1 delivered, 1 dropped", s)
+	}
+}
+
+// TestBusPublishAfterClose comprueba que los eventos publicados con el bus
+// cerrado se cuentan como descartados en vez de perderse en silencio
+func TestBusPublishAfterClose(t *testing.T) {
+	b := NewEventBus(context.Background())
+	sub := b.Subscribe("block", 4, OverflowBlock, EventFishCaught)
+	b.Subscribe("other", 4, OverflowDropNewest, EventCast)
+	b.Publish(GameEvent{Type: EventFishCaught})
+	b.Close()
+	b.Close() // Cerrar dos veces no debe fallar
+
+	b.Publish(GameEvent{Type: EventFishCaught})
+	b.Publish(GameEvent{Type: EventFishCaught})
+
+	if s := subStats(t, b, "block"); s.Delivered != 1 || s.Dropped != 2 {
+		t.Errorf("block stats = %+v, want 1 delivered, 2 dropped", s)
+	}
+	if s := subStats(t, b, "other"); s.Dropped != 0 {
+		t.Errorf("uninterested subscriber counted drops: %+v", s)
+	}
+
+	// El evento entregado antes de cerrar sigue en la cola
+	if _, ok := <-sub.C(); !ok {
+		t.Error("event delivered before Close was lost")
+	}
+	if _, ok := <-sub.C(); ok {
+		t.Error("channel still open after Close")
+	}
+}
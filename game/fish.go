@@ -2,14 +2,13 @@ package game
 
 import (
 	"context"
-	"image"
 	"math"
 	"math/rand"
+	"runtime/trace"
 	"sync"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 type FishType int
@@ -19,68 +18,74 @@ const (
 	FishRare
 	FishEpic
 	FishLegendary
-)
 
-// Sprites globales para peces (compartidos por todas las instancias)
-var (
-	fishSprites   map[FishType]*ebiten.Image
-	fishSpritesMu sync.Mutex
+	numFishTypes = 4 // Cantidad de tipos de pez
 )
 
+// String retorna el identificador del tipo de pez (usado en métricas y registros)
+func (t FishType) String() string {
+	switch t {
+	case FishCommon:
+		return "common"
+	case FishRare:
+		return "rare"
+	case FishEpic:
+		return "epic"
+	case FishLegendary:
+		return "legendary"
+	default:
+		return "unknown"
+	}
+}
+
+// Animaciones de natación por tipo de pez
+var fishAnimations = [...]string{
+	FishCommon:    "fish_common",
+	FishRare:      "fish_rare",
+	FishEpic:      "fish_epic",
+	FishLegendary: "fish_legendary",
+}
+
 type Fish struct {
-	X, Y      float64
-	vx, vy    float64 // Velocidad
-	FishType  FishType
-	
+	X, Y       float64
+	vx, vy     float64 // Velocidad
+	FishType   FishType
+	rng        *rand.Rand  // Generador propio (la goroutine del pez es la única que lo usa)
+	conditions *Conditions // Condiciones del lago (hora y clima); nil = normales
+
+	// Identidad: talla, peso, edad, rasgos y variante (ver identity.go)
+	FishIdentity
+
 	// Animación
-	frame      int
-	frameCount int
-	
+	anim AnimationPlayer
+
 	// Control de goroutine
-	mu       sync.Mutex
-	active   bool
+	mu     sync.Mutex
+	active bool
 }
 
-// LoadFishSprites carga todos los sprites de peces
-func LoadFishSprites() error {
-	fishSprites = make(map[FishType]*ebiten.Image)
-	
-	var err error
-	fishSprites[FishCommon], _, err = ebitenutil.NewImageFromFile("assets/fish_common.png")
-	if err != nil {
-		return err
-	}
-	
-	fishSprites[FishRare], _, err = ebitenutil.NewImageFromFile("assets/fish_rare.png")
-	if err != nil {
-		return err
-	}
-	
-	fishSprites[FishEpic], _, err = ebitenutil.NewImageFromFile("assets/fish_epic.png")
-	if err != nil {
-		return err
-	}
-	
-	fishSprites[FishLegendary], _, err = ebitenutil.NewImageFromFile("assets/fish_legendary.png")
-	if err != nil {
-		return err
-	}
-	
-	return nil
-}
+// NewFish crea un pez. rng se usa para derivar el generador propio del pez,
+// de modo que con la misma semilla el lago se comporta igual.
+// conditions puede ser nil (sin efectos de la hora ni del clima).
+func NewFish(x, y float64, fishType FishType, rng *rand.Rand, conditions *Conditions) *Fish {
+	fishRng := rand.New(rand.NewSource(rng.Int63()))
+	identity := newFishIdentity(fishType, fishRng)
 
-func NewFish(x, y float64, fishType FishType) *Fish {
 	// Velocidad aleatoria
-	angle := rand.Float64() * 2 * math.Pi
-	speed := 0.5 + rand.Float64()*1.0
-	
+	angle := fishRng.Float64() * 2 * math.Pi
+	speed := (0.5 + fishRng.Float64()*1.0) * identity.speedScale()
+
 	return &Fish{
-		X:        x,
-		Y:        y,
-		vx:       math.Cos(angle) * speed,
-		vy:       math.Sin(angle) * speed,
-		FishType: fishType,
-		active:   true,
+		X:            x,
+		Y:            y,
+		vx:           math.Cos(angle) * speed,
+		vy:           math.Sin(angle) * speed,
+		FishType:     fishType,
+		rng:          fishRng,
+		conditions:   conditions,
+		FishIdentity: identity,
+		anim:         AnimationPlayer{name: fishAnimations[fishType]},
+		active:       true,
 	}
 }
 
@@ -88,113 +93,112 @@ func NewFish(x, y float64, fishType FishType) *Fish {
 // Cada pez tiene su propia goroutine
 func (f *Fish) Swim(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
-	
+	ctx = labelGoroutine(ctx, "fish")
+
 	ticker := time.NewTicker(16 * time.Millisecond) // ~60 FPS
 	defer ticker.Stop()
-	
+
 	changeDirectionCounter := 0
-	
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
-			
+
 		case <-ticker.C:
+			region := trace.StartRegion(ctx, "fish.swim")
 			f.mu.Lock()
 			if !f.active {
 				f.mu.Unlock()
+				region.End()
 				return
 			}
-			
-			// Actualizar posición
-			f.X += f.vx
-			f.Y += f.vy
-			
+
+			// Actualizar posición (la velocidad depende de la hora y el clima)
+			speed := 1.0
+			if f.conditions != nil {
+				speed = f.conditions.SwimSpeed(f.FishType)
+			}
+			f.X += f.vx * speed
+			f.Y += f.vy * speed
+
 			// Cambiar dirección aleatoriamente cada cierto tiempo
 			changeDirectionCounter++
 			if changeDirectionCounter > 120 { // Cada ~2 segundos
 				changeDirectionCounter = 0
-				if rand.Float64() < 0.3 { // 30% de probabilidad
-					angle := rand.Float64() * 2 * math.Pi
-					speed := 0.5 + rand.Float64()*1.0
+				if f.rng.Float64() < 0.3 { // 30% de probabilidad
+					angle := f.rng.Float64() * 2 * math.Pi
+					speed := (0.5 + f.rng.Float64()*1.0) * f.speedScale()
 					f.vx = math.Cos(angle) * speed
 					f.vy = math.Sin(angle) * speed
 				}
 			}
-			
+
 			// Mantener dentro del lago (comportamiento de rebote)
 			dx := f.X - LakeCenterX
 			dy := f.Y - LakeCenterY
 			distance := math.Sqrt(dx*dx + dy*dy)
-			
+
 			if distance > LakeRadius-20 {
 				// Rebotar hacia el centro
 				angle := math.Atan2(dy, dx)
-				f.vx = -math.Cos(angle) * (0.5 + rand.Float64()*1.0)
-				f.vy = -math.Sin(angle) * (0.5 + rand.Float64()*1.0)
+				f.vx = -math.Cos(angle) * (0.5 + f.rng.Float64()*1.0) * f.speedScale()
+				f.vy = -math.Sin(angle) * (0.5 + f.rng.Float64()*1.0) * f.speedScale()
 			}
-			
+
 			// Actualizar frame de animación
-			f.frameCount++
-			if f.frameCount >= 15 {
-				f.frameCount = 0
-				f.frame = (f.frame + 1) % 2
-			}
-			
+			f.anim.Update()
+
 			f.mu.Unlock()
+			region.End()
 		}
 	}
 }
 
-// Draw dibuja el pez con efecto de sombra (bajo el agua)
-func (f *Fish) Draw(screen *ebiten.Image) {
+// Draw dibuja el pez con efecto de sombra (bajo el agua), escalado según su
+// talla y teñido según su variante de color.
+// visibility (0 a 1) reduce la opacidad, por ejemplo con niebla.
+func (f *Fish) Draw(screen *ebiten.Image, visibility float64) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	
-	fishSpritesMu.Lock()
-	sprite := fishSprites[f.FishType]
-	fishSpritesMu.Unlock()
-	
-	if sprite == nil {
-		return
-	}
-	
-	// Calcular tamaño del frame según el tipo de pez
-	frameWidth := 32  // Ajusta según tus sprites
-	frameHeight := 24 // Ajusta según tus sprites
-	
-	if f.FishType == FishLegendary {
-		frameWidth = 50
-		frameHeight = 40
-	} else if f.FishType == FishEpic {
-		frameWidth = 40
-		frameHeight = 32
-	}
-	
-	sx := f.frame * frameWidth
-	sy := 0
-	
+
 	op := &ebiten.DrawImageOptions{}
-	
+	scale := f.sizeScale(f.FishType)
+	op.GeoM.Scale(scale, scale)
+	tint := variantTints[f.Variant]
+	op.ColorScale.Scale(tint[0], tint[1], tint[2], 1)
+
 	// Efecto de sombra bajo el agua (semi-transparente y oscurecido)
 	op.ColorScale.Scale(0.7, 0.7, 0.9, 0.7) // Darken y transparencia
-	
-	op.GeoM.Translate(-float64(frameWidth)/2, -float64(frameHeight)/2)
-	op.GeoM.Translate(f.X, f.Y)
-	
-	subImg := sprite.SubImage(image.Rect(sx, sy, sx+frameWidth, sy+frameHeight)).(*ebiten.Image)
-	screen.DrawImage(subImg, op)
+	op.ColorScale.ScaleAlpha(float32(visibility))
+
+	f.anim.DrawAt(screen, f.X, f.Y, op)
+}
+
+// setPosition mueve el pez (sólo cuando su goroutine ya no lo mueve,
+// por ejemplo mientras pelea un legendario)
+func (f *Fish) setPosition(x, y float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.X, f.Y = x, y
+}
+
+// Position retorna la posición actual del pez
+func (f *Fish) Position() (float64, float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.X, f.Y
 }
 
 // CheckCollision verifica si el pez colisionó con un punto (anzuelo)
 func (f *Fish) CheckCollision(x, y, radius float64) bool {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	
+
 	dx := f.X - x
 	dy := f.Y - y
 	distance := math.Sqrt(dx*dx + dy*dy)
-	
+
 	return distance < radius
 }
 
@@ -203,4 +207,4 @@ func (f *Fish) Stop() {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	f.active = false
-}
\ No newline at end of file
+}
@@ -6,11 +6,16 @@ import (
 	"image/color"
 	_ "image/png"
 	"math/rand"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/trace"
 	"sync"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 const (
@@ -26,6 +31,14 @@ const (
 var (
 	// color de fallback si no hay imagen
 	colorLake = color.RGBA{40, 140, 200, 255}
+
+	// Fondos semi-transparentes de los paneles
+	colorPanel   = color.RGBA{0, 0, 0, 160}
+	colorToast   = color.RGBA{20, 20, 20, 200}
+	colorOverlay = color.RGBA{0, 0, 0, 180}
+	colorLine    = color.RGBA{128, 128, 128, 204} // Línea de pesca gris
+	colorSplash  = color.RGBA{200, 220, 230, 230} // Chapoteo del bobber
+	colorTension = color.RGBA{220, 60, 40, 255}   // Barra de tensión de la pelea
 )
 
 type GameState int
@@ -35,16 +48,48 @@ const (
 	StatePlaying
 	StateFishing
 	StateCaught
+	StateFighting
 )
 
+// String retorna el nombre del estado (para el log de transiciones)
+func (s GameState) String() string {
+	switch s {
+	case StateMenu:
+		return "Menu"
+	case StatePlaying:
+		return "Playing"
+	case StateFishing:
+		return "Fishing"
+	case StateCaught:
+		return "Caught"
+	case StateFighting:
+		return "Fighting"
+	default:
+		return "Unknown"
+	}
+}
+
 // Game implementa ebiten.Game interface
 type Game struct {
-	// Sincronización
-	mu     sync.Mutex
+	// Configuración (con la dificultad ya aplicada) y generador aleatorio
+	cfg Config
+	rng *rand.Rand
+
+	// Sincronización (timedMutex mide la espera para las métricas)
+	mu     timedMutex
 	wg     sync.WaitGroup
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	// Cierre ordenado (ver shutdown.go)
+	spawnCtx       context.Context
+	stopSpawner    context.CancelFunc
+	spawnerDone    chan struct{}
+	catchesDone    chan struct{}
+	shutdownOnce   sync.Once
+	shutdownErr    error
+	baseGoroutines int
+
 	// Estado del juego
 	state      GameState
 	score      int
@@ -56,54 +101,173 @@ type Game struct {
 	epicCount      int
 	legendaryCount int
 
+	// Última captura (la escribe catchProcessor)
+	lastCatch CatchRecord
+
 	// Entidades
 	player *Player
 	bobber *Bobber
+	line   FishingLine
 	fishes []*Fish
 
-	// Canales para concurrencia (Patrón Productor-Consumidor)
-	spawnChan chan *Fish
-	catchChan chan FishType
+	// Efectos: chapoteos, ondas y brillos (ver particles.go)
+	particles *ParticleSystem
+
+	// Reloj del día y clima (sólo los avanza Step) y las condiciones del lago
+	// que derivan de ellos, leídas por el spawner y los peces (ver clock.go y weather.go)
+	clock      GameClock
+	weather    *Weather
+	conditions *Conditions
+
+	// Abundancia de cada especie y su historial (ver ecology.go). El
+	// gráfico de población (G) copia el historial a graphSamples al dibujar.
+	ecology      *Ecology
+	graphVisible bool
+	graphSamples []PopulationSample
+
+	// Encuentro con un legendario (ver legendary.go) y si el jugador
+	// mantiene el botón de recoger durante la pelea
+	encounter LegendaryEncounter
+	reeling   bool
+
+	// Bus de eventos (Patrón Productor-Consumidor con múltiples suscriptores)
+	bus            *EventBus
+	spawnSub       *Subscription
+	catchSub       *Subscription
+	achievementSub *Subscription
+
+	// Logros
+	achievements *AchievementEngine
+
+	// Persistencia (vacío = no guardar, usado por el modo headless)
+	savePath string
+
+	// Máquina de estados (ver statemachine.go). Sólo la goroutine de
+	// Update escribe state, player y bobber; las demás envían comandos.
+	commands      chan command
+	castSeq       int
+	transitionLog [transitionLogSize]Transition
+	transitionN   int
 
 	// Assets
-	lakeScene *ebiten.Image
+	assets        *AssetManager
+	assetsVersion int64
+	lakeScene     *ebiten.Image
 
 	// Control de tiempo
 	frameCount int
+
+	// Métricas de ejecución y overlay de depuración
+	metrics       Metrics
+	metricsServer *http.Server
+	metricsAddr   string // Dirección real del listener (con el puerto elegido si era :0)
+
+	// Textos del HUD (ver drawUI)
+	hud hudCache
+
+	// Perfilado con F5 (CPU + traza) y F6 (heap), ver profiling.go
+	profiler *Profiler
+}
+
+// NewGame crea una nueva instancia del juego con la configuración dada
+func NewGame(cfg Config) (*Game, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := os.MkdirAll(cfg.SaveDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating save dir: %w", err)
+	}
+	return newGame(cfg, false)
+}
+
+// NewHeadlessGame crea el juego sin cargar sprites ni tocar archivos de guardado.
+// Las goroutines funcionan igual que en el juego normal, por lo que sirve para
+// ejecutar la simulación (Step, Cast, Reel) en pruebas con -race.
+// Los directorios de cfg no se usan, pero la configuración se valida igual
+// que en NewGame, así que conviene partir de DefaultConfig.
+func NewHeadlessGame(cfg Config) (*Game, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return newGame(cfg, true)
 }
 
-// NewGame crea una nueva instancia del juego
-func NewGame() (*Game, error) {
-	// Inicializar random seed
-	rand.Seed(time.Now().UnixNano())
+func newGame(cfg Config, headless bool) (*Game, error) {
+	cfg = cfg.withDifficulty()
 
-	// Crear contexto para cancelación
+	// Crear contexto para cancelación (el spawner tiene el suyo para detenerlo primero)
 	ctx, cancel := context.WithCancel(context.Background())
+	spawnCtx, stopSpawner := context.WithCancel(ctx)
 
 	g := &Game{
-		state:     StatePlaying,
-		ctx:       ctx,
-		cancel:    cancel,
-		fishes:    make([]*Fish, 0),
-		spawnChan: make(chan *Fish, 10),
-		catchChan: make(chan FishType, 10),
+		cfg:    cfg,
+		rng:    newRand(cfg.Seed),
+		state:  StatePlaying,
+		ctx:    ctx,
+		cancel: cancel,
+		fishes: make([]*Fish, 0),
+		bus:    NewEventBus(ctx),
+
+		clock:      NewGameClock(cfg.DayLength.Duration, 0, startMinutes),
+		conditions: NewConditions(),
+
+		commands: make(chan command, 32),
+
+		spawnCtx:       spawnCtx,
+		stopSpawner:    stopSpawner,
+		spawnerDone:    make(chan struct{}),
+		catchesDone:    make(chan struct{}),
+		baseGoroutines: runtime.NumGoroutine(),
+
+		achievements: NewAchievementEngine(cfg.savePath(achievementsFile)),
+		savePath:     cfg.savePath(saveFile),
+	}
+	if headless {
+		g.achievements = NewAchievementEngine("")
+		g.savePath = ""
 	}
 
-	// Inicializar jugador (fuera del lago)
-	g.player = NewPlayer(float64(LakeCenterX), float64(LakeCenterY+LakeRadius+40))
-	if err := g.player.LoadSprites(); err != nil {
-		return nil, fmt.Errorf("error loading player sprites: %w", err)
+	// Suscriptores del bus
+	g.spawnSub = g.bus.Subscribe("spawn", 10, OverflowDropNewest, EventFishSpawned)
+	// Las capturas nunca se descartan: si la cola está llena el publicador espera
+	g.catchSub = g.bus.Subscribe("catch", 10, OverflowBlock, EventFishCaught)
+	g.achievementSub = g.bus.Subscribe("achievements", 64, OverflowDropOldest,
+		EventFishCaught, EventFishEscaped, EventCast, EventReel)
+
+	g.ecology = NewEcology(g.rng)
+	g.encounter = NewLegendaryEncounter(g.rng)
+
+	// Cargar progreso de logros y partida guardada
+	if err := g.achievements.Load(); err != nil {
+		fmt.Println("Warning: failed to load achievements:", err)
+	}
+	if data, ok, err := LoadSave(g.savePath); err != nil {
+		fmt.Println("Warning: failed to load save:", err)
+	} else if ok {
+		g.applySave(data)
 	}
+	g.conditions.apply(&g.clock, WeatherClear)
 
-	// Inicializar bobber
+	// Inicializar jugador (fuera del lago) y bobber
+	g.player = NewPlayer(float64(LakeCenterX), float64(LakeCenterY+LakeRadius+40))
 	g.bobber = NewBobber()
-	if err := g.bobber.LoadSprites(); err != nil {
-		return nil, fmt.Errorf("error loading bobber sprites: %w", err)
-	}
+	g.particles = NewParticleSystem(g.rng)
+	g.weather = NewWeather(g.rng)
 
-	// Cargar assets
-	if err := g.loadAssets(); err != nil {
-		return nil, fmt.Errorf("error loading assets: %w", err)
+	// Cargar assets (los faltantes se reemplazan por placeholders)
+	if !headless {
+		g.assets = NewAssetManager(cfg.Assets, cfg.AssetDir)
+		g.loadAssets()
+		g.assetsVersion = g.assets.Version()
+
+		// Recarga en caliente al modificar el directorio de assets
+		if cfg.HotReload && cfg.AssetDir != "" {
+			g.wg.Add(1)
+			go func() {
+				defer g.wg.Done()
+				g.assets.Watch(g.ctx)
+			}()
+		}
 	}
 
 	// Iniciar goroutines del patrón Productor-Consumidor
@@ -113,201 +277,412 @@ func NewGame() (*Game, error) {
 	g.wg.Add(1)
 	go g.catchProcessor() // CONSUMIDOR (en spawner.go)
 
+	g.wg.Add(1)
+	go g.achievementProcessor() // CONSUMIDOR de eventos (en achievements.go)
+
 	return g, nil
 }
 
-// loadAssets carga todas las imágenes necesarias
-func (g *Game) loadAssets() error {
-	var err error
+// loadAssets carga (o recarga) todas las imágenes necesarias
+func (g *Game) loadAssets() {
+	// Hojas de sprites y animaciones de jugador, bobber y peces (globales, compartidas)
+	LoadAnimations(g.assets)
+
+	// El escenario del lago es opcional: sin él se usa un color de fondo
+	g.lakeScene, _ = g.assets.Lookup("lake_scene.png")
+}
 
-	// Intentar cargar escenario del lago
-	g.lakeScene, _, err = ebitenutil.NewImageFromFile("assets/lake_scene.png")
-	if err != nil {
-		g.lakeScene = nil
-		fmt.Println("Warning: failed to load lake_scene.png, using color background:", err)
+// reloadChangedAssets recarga los sprites si cambió el directorio de assets
+func (g *Game) reloadChangedAssets() {
+	if g.assets == nil {
+		return
+	}
+	if v := g.assets.Version(); v != g.assetsVersion {
+		g.assetsVersion = v
+		g.loadAssets()
 	}
+}
 
-	// Cargar sprites de peces (globales, compartidos)
-	if err := LoadFishSprites(); err != nil {
-		return fmt.Errorf("failed to load fish sprites: %w", err)
+// Update actualiza la lógica del juego (60 FPS)
+func (g *Game) Update() error {
+	// Cerrar la ventana o presionar ESC termina el juego;
+	// el cierre ordenado lo hace Shutdown después de RunGame
+	if ebiten.IsWindowBeingClosed() || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return ebiten.Termination
+	}
+
+	// Recargar sprites modificados (hot reload)
+	g.reloadChangedAssets()
+
+	// Manejar input del usuario
+	g.handleInput()
+
+	// Actualizar jugador (solo si está jugando, no en modo pesca)
+	if g.State() == StatePlaying {
+		g.player.Update()
 	}
 
+	start := time.Now()
+	g.Step()
+	g.observeTick(time.Since(start))
 	return nil
 }
 
-// Update actualiza la lógica del juego (60 FPS)
-func (g *Game) Update() error {
+// Step avanza un tick de la simulación sin leer el teclado.
+// Update lo llama cada frame; el modo headless lo llama directamente.
+func (g *Game) Step() {
+	defer trace.StartRegion(g.ctx, "game.step").End()
 	g.frameCount++
 
-	// Procesar nuevos peces del canal (CONSUMIDOR)
+	// Aplicar los comandos enviados por otras goroutines
+	g.processCommands()
+
+	// Procesar nuevos peces del bus (CONSUMIDOR)
 	select {
-	case fish := <-g.spawnChan:
+	case ev, ok := <-g.spawnSub.C():
+		if !ok {
+			break
+		}
+		fish := ev.Fish
 		g.mu.Lock()
 		g.fishes = append(g.fishes, fish)
+		if fish.FishType == FishLegendary && !g.encounter.Active() {
+			g.encounter.begin(fish)
+		}
 		g.mu.Unlock()
 
 		// Iniciar goroutine para el movimiento del pez
 		g.wg.Add(1)
 		go fish.Swim(g.ctx, &g.wg)
 	default:
-		// No hay peces nuevos en el canal
+		// No hay peces nuevos en la cola
 	}
 
-	// Manejar input del usuario
-	g.handleInput()
-
-	// Actualizar jugador (solo si está jugando, no en modo pesca)
-	if g.state == StatePlaying {
-		g.player.Update()
+	// Animar al jugador y al bobber; detectar colisiones si el bobber está activo
+	g.mu.Lock()
+	clockChanged := g.clock.Tick()
+	weatherChanged := g.weather.Tick()
+	if clockChanged || weatherChanged {
+		g.changeConditions()
+	}
+	g.player.Animate()
+	if g.bobber.Update() {
+		g.particles.EmitCastSplash(g.bobber.X, g.bobber.Y)
 	}
-
-	// Actualizar bobber (animación)
-	g.bobber.Update()
-
-	// Detectar colisiones con peces si el bobber está activo
 	if g.bobber.active {
+		// El viento arrastra el bobber, sin sacarlo del lago
+		dx, dy := g.weather.Drift()
+		g.bobber.Drift(dx, dy)
+	}
+	g.updateLine()
+	bobberActive := g.bobber.active
+	g.updateEffects()
+	g.updateEcology()
+	g.mu.Unlock()
+
+	if bobberActive {
 		g.checkFishCollisions()
 	}
 
 	// Limpiar peces que salieron del lago
 	g.cleanupFishes()
 
-	return nil
+	// Tiempo en el lago y pelea del legendario
+	g.updateEncounter()
+}
+
+// changeConditions aplica una nueva fase del día, estación o clima: ajusta
+// las condiciones del lago y los peces de especies que dejan de estar
+// activas se van del lago. Irse por las condiciones no es un escape, así que
+// no publica EventFishEscaped (que cuenta para el logro de escapes).
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) changeConditions() {
+	g.conditions.apply(&g.clock, g.weather.Kind())
+
+	kept := 0
+	for _, fish := range g.fishes {
+		if g.conditions.Active(fish.FishType) {
+			g.fishes[kept] = fish
+			kept++
+		} else {
+			fish.Stop()
+		}
+	}
+	clear(g.fishes[kept:])
+	g.fishes = g.fishes[:kept]
+}
+
+// updateEffects alimenta los emisores continuos y avanza las partículas:
+// ondas cuando un pez ronda el bobber y la estela de los legendarios
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) updateEffects() {
+	if g.bobber.active && g.frameCount%rippleInterval == 0 {
+		for _, fish := range g.fishes {
+			if fish.CheckCollision(g.bobber.X, g.bobber.Y, g.cfg.CatchRadius*3) {
+				g.particles.EmitBiteRipple(g.bobber.X, g.bobber.Y)
+				break
+			}
+		}
+	}
+
+	if g.frameCount%sparkleInterval == 0 {
+		for _, fish := range g.fishes {
+			if fish.FishType == FishLegendary {
+				g.particles.EmitSparkle(fish.Position())
+			}
+		}
+	}
+
+	g.weather.EmitEffects(g.particles)
+	g.particles.Update()
+}
+
+// updateLine tensa la línea mientras hay un pez enganchado y la afloja al pescar
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) updateLine() {
+	switch g.state {
+	case StateCaught:
+		g.line.SetTarget(1, 1)
+	case StateFighting:
+		// La línea vibra más mientras el pez salta
+		wobble := 0.5
+		if g.encounter.jumpTicks > 0 {
+			wobble = 1
+		}
+		g.line.SetTarget(g.encounter.Tension(), wobble)
+	default:
+		g.line.SetTarget(0, 0)
+	}
+	g.line.Update()
 }
 
 // Draw dibuja el juego en la pantalla
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Dibujar escenario
+	// Dibujar escenario teñido según la hora del día
+	g.mu.Lock()
+	r, gr, b := g.clock.Tint()
+	g.mu.Unlock()
 	if g.lakeScene != nil {
-		screen.DrawImage(g.lakeScene, nil)
+		op := &ebiten.DrawImageOptions{}
+		op.ColorScale.Scale(r, gr, b, 1)
+		screen.DrawImage(g.lakeScene, op)
 	} else {
-		screen.Fill(colorLake)
+		fillRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{
+			uint8(float32(colorLake.R) * r), uint8(float32(colorLake.G) * gr), uint8(float32(colorLake.B) * b), colorLake.A})
 	}
 
-	// Dibujar peces (con efecto de sombra bajo el agua)
+	// Dibujar peces (con efecto de sombra bajo el agua) y encima
+	// los efectos de la superficie (ondas, chapoteos, brillos)
 	g.mu.Lock()
+	visibility := g.weather.FishVisibility()
 	for _, fish := range g.fishes {
-		fish.Draw(screen)
+		fish.Draw(screen, visibility)
 	}
+	g.particles.Draw(screen, LayerSurface)
+	g.weather.DrawFog(screen)
 	g.mu.Unlock()
 
-	// Dibujar bobber (antes del jugador para que quede "en el agua")
-	if g.bobber.active {
-		g.bobber.Draw(screen)
-	}
-
-	// Dibujar jugador
+	// Dibujar bobber (antes del jugador para que quede "en el agua"), las gotas
+	// en el aire, el jugador y la línea de pesca desde la punta de la caña
+	g.mu.Lock()
+	g.bobber.Draw(screen)
+	g.particles.Draw(screen, LayerAir)
 	g.player.Draw(screen)
+	if tipX, tipY, ok := g.player.RodTip(); ok && g.bobber.Visible() {
+		bx, by := g.bobber.LineAnchor()
+		g.line.Draw(screen, tipX, tipY, bx, by)
+	}
+	g.weather.DrawRain(screen)
+	g.mu.Unlock()
 
 	// Dibujar UI (puntuación, estadísticas)
 	g.drawUI(screen)
+
+	// Notificaciones de logros
+	g.achievements.DrawToasts(screen)
+
+	// Legendario peleando, barras de la pelea y anuncios
+	g.drawEncounter(screen, visibility)
+
+	// Gráfico de población (G)
+	g.drawPopulationGraph(screen)
+
+	// Métricas de depuración (F3)
+	g.drawDebugOverlay(screen)
+}
+
+// hudValues son los datos que muestra el HUD
+type hudValues struct {
+	score, fishCaught             int
+	common, rare, epic, legendary int
+	inLake                        [4]int
+	achievements                  int
+	hour, minute                  int
+	phase                         DayPhase
+	lastCatch                     CatchRecord
+	weather                       WeatherKind
+	season                        Season
+	seasonDay                     int
+	migration                     bool
+}
+
+// hudCache guarda los textos del HUD junto con los valores con que se
+// formatearon; sólo se vuelven a formatear cuando algún valor cambia,
+// así dibujar el HUD no reserva memoria en cada frame.
+// Sólo lo usa la goroutine de Draw.
+type hudCache struct {
+	values hudValues
+	lines  [12]string
+	valid  bool
 }
 
+// update reformatea los textos si los valores cambiaron
+func (h *hudCache) update(v hudValues) {
+	if h.valid && v == h.values {
+		return
+	}
+	h.values = v
+	h.valid = true
+
+	h.lines[0] = fmt.Sprintf("Puntos: %d", v.score)
+	h.lines[1] = fmt.Sprintf("Total Capturados: %d", v.fishCaught)
+	h.lines[2] = fmt.Sprintf("Comunes: %d", v.common)
+	h.lines[3] = fmt.Sprintf("Raros: %d", v.rare)
+	h.lines[4] = fmt.Sprintf("Épicos: %d", v.epic)
+	h.lines[5] = fmt.Sprintf("Legendarios: %d", v.legendary)
+	h.lines[6] = fmt.Sprintf("En el Lago: %d C, %d R, %d E, %d L",
+		v.inLake[FishCommon], v.inLake[FishRare], v.inLake[FishEpic], v.inLake[FishLegendary])
+	h.lines[7] = fmt.Sprintf("Logros: %d/%d", v.achievements, len(achievementDefs))
+	h.lines[8] = fmt.Sprintf("Hora: %02d:%02d (%s)", v.hour, v.minute, v.phase)
+	h.lines[9] = fmt.Sprintf("Clima: %s", v.weather)
+	h.lines[10] = fmt.Sprintf("Estación: %s (día %d/%d)", v.season, v.seasonDay, daysPerSeason)
+	if v.migration {
+		h.lines[10] += " ¡Migración!"
+	}
+	h.lines[11] = ""
+	if c := v.lastCatch; c.FishID != 0 {
+		h.lines[11] = fmt.Sprintf("Última: %.0f cm, %.2f kg, %s (+%d)", c.Size, c.Weight, c.Variant, c.Points)
+	}
+}
+
+// Posición vertical de cada línea del HUD
+var hudLineY = [...]int{20, 36, 56, 76, 96, 116, 136, 156, 172, 188, 204, 220}
+
 // drawUI dibuja la interfaz de usuario
 func (g *Game) drawUI(screen *ebiten.Image) {
 	// Fondo semi-transparente
-	uiRect := ebiten.NewImage(240, 160)
-	uiRect.Fill(color.RGBA{0, 0, 0, 160})
-
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(10, 10)
-	screen.DrawImage(uiRect, op)
+	fillRect(screen, 10, 10, 240, 228, colorPanel)
 
 	// Obtener datos con mutex
+	var v hudValues
 	g.mu.Lock()
-	scoreText := fmt.Sprintf("Puntos: %d", g.score)
-	totalText := fmt.Sprintf("Total Capturados: %d", g.fishCaught)
-	commonText := fmt.Sprintf("Comunes: %d", g.commonCount)
-	rareText := fmt.Sprintf("Raros: %d", g.rareCount)
-	epicText := fmt.Sprintf("Épicos: %d", g.epicCount)
-	legendText := fmt.Sprintf("Legendarios: %d", g.legendaryCount)
-	
+	v.score = g.score
+	v.fishCaught = g.fishCaught
+	v.common = g.commonCount
+	v.rare = g.rareCount
+	v.epic = g.epicCount
+	v.legendary = g.legendaryCount
+	v.lastCatch = g.lastCatch
+
 	// Contar peces en el lago por tipo
-	commonInLake := g.countFishType(FishCommon)
-	rareInLake := g.countFishType(FishRare)
-	epicInLake := g.countFishType(FishEpic)
-	legendInLake := g.countFishType(FishLegendary)
+	for t := FishCommon; t <= FishLegendary; t++ {
+		v.inLake[t] = g.countFishType(t)
+	}
+	v.hour, v.minute = g.clock.HourMinute()
+	v.phase = g.clock.Phase()
+	v.weather = g.weather.Kind()
+	v.season = g.clock.Season()
+	v.seasonDay = g.clock.SeasonDay()
+	v.migration = g.clock.Migration()
 	g.mu.Unlock()
+	v.achievements = g.achievements.UnlockedCount()
 
-	// Mostrar estadísticas
-	ebitenutil.DebugPrintAt(screen, scoreText, 20, 20)
-	ebitenutil.DebugPrintAt(screen, totalText, 20, 36)
-	ebitenutil.DebugPrintAt(screen, commonText, 20, 56)
-	ebitenutil.DebugPrintAt(screen, rareText, 20, 76)
-	ebitenutil.DebugPrintAt(screen, epicText, 20, 96)
-	ebitenutil.DebugPrintAt(screen, legendText, 20, 116)
-	
-	// Mostrar peces en el lago
-	lakeInfo := fmt.Sprintf("En el Lago: %d C, %d R, %d E, %d L", 
-		commonInLake, rareInLake, epicInLake, legendInLake)
-	ebitenutil.DebugPrintAt(screen, lakeInfo, 20, 136)
+	// Mostrar estadísticas, peces en el lago y logros
+	g.hud.update(v)
+	for i, line := range g.hud.lines {
+		ebitenutil.DebugPrintAt(screen, line, 20, hudLineY[i])
+	}
 
 	// Controles
-	ebitenutil.DebugPrintAt(screen, "WASD: Mover | ESPACIO: Lanzar | R: Recoger", 10, ScreenHeight-20)
+	ebitenutil.DebugPrintAt(screen, "WASD: Mover | ESPACIO: Lanzar | R: Recoger | G: Población", 10, ScreenHeight-20)
 }
 
-// handleInput maneja la entrada del usuario
+// handleInput maneja la entrada del usuario.
+// Se ejecuta en la goroutine dueña del estado, así que aplica los comandos directamente.
 func (g *Game) handleInput() {
 	// Lanzar anzuelo con ESPACIO
-	if ebiten.IsKeyPressed(ebiten.KeySpace) && g.state == StatePlaying {
-		if g.player.IsNearWater() {
-			g.state = StateFishing
-			g.player.Cast()
-			g.bobber.Cast(g.player.X, g.player.Y)
-		}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.dispatch(command{cmd: CmdCast})
 	}
 
 	// Recoger anzuelo con R
-	if ebiten.IsKeyPressed(ebiten.KeyR) && g.state == StateFishing {
-		g.state = StatePlaying
-		g.bobber.Reset()
-		g.player.StopFishing()
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.dispatch(command{cmd: CmdReel})
+	}
+
+	// Mantener ESPACIO recoge durante la pelea con un legendario
+	g.HoldReel(ebiten.IsKeyPressed(ebiten.KeySpace))
+
+	// Gráfico de población con G
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		g.graphVisible = !g.graphVisible
+	}
+
+	// Overlay de métricas con F3 y exportación a CSV con F4
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		g.toggleOverlay()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		g.toggleMetricsRecording()
+	}
+
+	// Perfil de CPU + traza con F5 y snapshot de heap con F6
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		g.toggleProfiling()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		g.writeHeapSnapshot()
 	}
 }
 
 // checkFishCollisions verifica si el anzuelo tocó algún pez
 func (g *Game) checkFishCollisions() {
+	if caught := g.findCaughtFish(); caught != nil {
+		// El legendario del encuentro no se captura de una: empieza una pelea
+		if g.isEncounterFish(caught) && g.dispatch(command{cmd: CmdStrike}) {
+			return
+		}
+		g.dispatch(command{cmd: CmdHook, fishType: caught.FishType})
+
+		// Publicar la captura FUERA del mutex: la cola de capturas bloquea
+		// cuando está llena y catchProcessor necesita g.mu para vaciarla
+		record := newCatchRecord(caught, g.getPointsForFish(caught.FishType))
+		g.bus.Publish(GameEvent{Type: EventFishCaught, FishType: caught.FishType, Catch: record})
+	}
+}
+
+// findCaughtFish retira del lago el pez que tocó el anzuelo (si hay alguno)
+func (g *Game) findCaughtFish() *Fish {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
 	for i, fish := range g.fishes {
-		if fish.CheckCollision(g.bobber.X, g.bobber.Y, 15) {
-			// ¡Pez capturado!
-			
-			// IMPORTANTE: Desactivar bobber INMEDIATAMENTE para evitar múltiples capturas
-			g.bobber.active = false
-			g.bobber.SetState(BobberCaught)
-			
-			// Enviar al canal para que catchProcessor lo procese
-			select {
-			case g.catchChan <- fish.FishType:
-			default:
-			}
-
-			// Remover pez de la lista
+		// La lluvia aumenta las picadas agrandando el radio de captura, y cada
+		// pez se acerca más o menos al anzuelo según su personalidad
+		if fish.CheckCollision(g.bobber.X, g.bobber.Y, g.cfg.CatchRadius*g.weather.BiteRadiusScale()*fish.biteScale()) {
+			// ¡Pez capturado! El comando CmdHook desactiva el bobber
+			// antes del siguiente frame, evitando múltiples capturas
+
+			// Remover pez de la lista. La abundancia se descuenta recién al
+			// capturarlo (CmdHook o CmdLand): un legendario que rompe la
+			// línea o se suelta se escapa sin afectar la población
 			g.fishes = append(g.fishes[:i], g.fishes[i+1:]...)
 			fish.Stop()
 
-			// Iniciar goroutine para resetear después de captura
-			g.wg.Add(1)
-			go g.resetAfterCatch()
-
-			break // Solo capturar UN pez
+			return fish // Solo capturar UN pez
 		}
 	}
-}
-
-// resetAfterCatch vuelve al modo de juego normal después de capturar un pez
-func (g *Game) resetAfterCatch() {
-	defer g.wg.Done()
-	
-	time.Sleep(1 * time.Second)
-	
-	g.mu.Lock()
-	g.state = StatePlaying
-	g.bobber.Reset()
-	g.player.StopFishing()
-	g.mu.Unlock()
+	return nil
 }
 
 // cleanupFishes elimina peces que están muy lejos del lago
@@ -315,20 +690,21 @@ func (g *Game) cleanupFishes() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	validFishes := make([]*Fish, 0, len(g.fishes))
+	// Filtrar en el mismo slice para no reservar memoria en cada tick
+	kept := 0
 	for _, fish := range g.fishes {
 		// Mantener solo peces dentro de un área razonable
-		dx := fish.X - LakeCenterX
-		dy := fish.Y - LakeCenterY
-		distance := dx*dx + dy*dy
-
-		if distance < (LakeRadius+100)*(LakeRadius+100) {
-			validFishes = append(validFishes, fish)
+		if fish.CheckCollision(LakeCenterX, LakeCenterY, LakeRadius+100) {
+			g.fishes[kept] = fish
+			kept++
 		} else {
 			fish.Stop()
+			g.bus.Publish(GameEvent{Type: EventFishEscaped, FishType: fish.FishType})
 		}
 	}
-	g.fishes = validFishes
+	// Soltar las referencias del final para que los peces eliminados se liberen
+	clear(g.fishes[kept:])
+	g.fishes = g.fishes[:kept]
 }
 
 // countFishType cuenta cuántos peces de un tipo hay en el lago
@@ -348,15 +724,15 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return ScreenWidth, ScreenHeight
 }
 
-// Cleanup limpia recursos al cerrar
+// Cleanup limpia recursos al cerrar (cierre ordenado con el tiempo por defecto)
 func (g *Game) Cleanup() {
-	g.cancel()           // Cancelar todas las goroutines
-	close(g.spawnChan)   // Cerrar canales
-	close(g.catchChan)
-	g.wg.Wait()          // Esperar a que todas las goroutines terminen
+	if err := g.Shutdown(DefaultShutdownTimeout); err != nil {
+		fmt.Println("Warning: shutdown:", err)
+	}
 }
 
-// getPointsForFish retorna los puntos según el tipo de pez
+// getPointsForFish retorna los puntos base según el tipo de pez
+// (la captura los escala por la talla y la variante, ver newCatchRecord)
 // Esta función es usada por catchProcessor en spawner.go
 func (g *Game) getPointsForFish(fishType FishType) int {
 	switch fishType {
@@ -371,4 +747,4 @@ func (g *Game) getPointsForFish(fishType FishType) int {
 	default:
 		return 0
 	}
-}
\ No newline at end of file
+}
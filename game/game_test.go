@@ -0,0 +1,318 @@
+package game
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// newTestGame crea un juego headless con semilla fija y lo cierra al terminar la prueba
+func newTestGame(t testing.TB, cfg Config) *Game {
+	t.Helper()
+	if cfg.Seed == 0 {
+		cfg.Seed = 1
+	}
+	g, err := NewHeadlessGame(cfg)
+	if err != nil {
+		t.Fatalf("NewHeadlessGame: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := g.Shutdown(DefaultShutdownTimeout); err != nil {
+			t.Errorf("Shutdown: %v", err)
+		}
+	})
+	return g
+}
+
+// stopTestSpawner detiene el spawner para que sólo haya los peces que agrega la prueba
+func stopTestSpawner(g *Game) {
+	g.stopSpawner()
+	<-g.spawnerDone
+}
+
+// baitFish pone un pez común (que muerde desde lejos) sobre el bobber y lo hace nadar
+func baitFish(g *Game) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	fish := NewFish(g.bobber.X, g.bobber.Y, FishCommon, g.rng, g.conditions)
+	fish.Traits.Wariness = 0
+	g.fishes = append(g.fishes, fish)
+	g.wg.Add(1)
+	go fish.Swim(g.ctx, &g.wg)
+}
+
+// stepUntil avanza la simulación hasta que cond se cumpla
+func stepUntil(t *testing.T, g *Game, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s (state %v)", what, g.State())
+		}
+		g.Step()
+	}
+}
+
+// bobberActive indica si el bobber está en el agua y puede capturar
+func bobberActive(g *Game) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.bobber.active
+}
+
+// hook lanza el anzuelo y captura un pez puesto sobre el bobber
+func hook(t *testing.T, g *Game) {
+	t.Helper()
+	g.Cast()
+	stepUntil(t, g, "bobber in the water", func() bool { return bobberActive(g) })
+	baitFish(g)
+	stepUntil(t, g, "hook", func() bool { return g.State() == StateCaught })
+}
+
+// waitGoroutines espera a que las goroutines vivas vuelvan a la línea base
+func waitGoroutines(t *testing.T, base int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > base {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines after shutdown: %d, baseline: %d", runtime.NumGoroutine(), base)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHeadlessGameRejectsInvalidConfig(t *testing.T) {
+	if _, err := NewHeadlessGame(Config{}); err == nil {
+		t.Fatal("NewHeadlessGame(Config{}) returned no error")
+	}
+}
+
+// TestCatchThousandsNoLeak genera y captura miles de peces (correr con -race)
+// y comprueba que después de Shutdown no queda ninguna goroutine del juego
+func TestCatchThousandsNoLeak(t *testing.T) {
+	const catches = 2000
+
+	cfg := DefaultConfig()
+	cfg.Seed = 42
+	cfg.CatchResetDelay = Duration{0}
+	g, err := NewHeadlessGame(cfg)
+	if err != nil {
+		t.Fatalf("NewHeadlessGame: %v", err)
+	}
+
+	hooked := 0
+	deadline := time.Now().Add(30 * time.Second)
+	for hooked < catches {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d of %d catches before timeout", hooked, catches)
+		}
+		switch g.State() {
+		case StatePlaying:
+			g.Cast()
+		case StateFishing:
+			if bobberActive(g) {
+				baitFish(g)
+			}
+		case StateFighting:
+			g.Reel() // Soltar al legendario: la prueba es de capturas comunes
+		case StateCaught:
+			// Dar tiempo a que resetAfterCatch envíe CmdCatchDone
+			time.Sleep(50 * time.Microsecond)
+		}
+		prev := g.State()
+		g.Step()
+		if prev == StateFishing && g.State() == StateCaught {
+			hooked++
+		}
+	}
+
+	if err := g.Shutdown(DefaultShutdownTimeout); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	waitGoroutines(t, g.baseGoroutines)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if published := g.bus.Published(EventFishCaught); int64(g.fishCaught) != published {
+		t.Errorf("fishCaught = %d, published catches = %d", g.fishCaught, published)
+	}
+	if g.fishCaught < catches {
+		t.Errorf("fishCaught = %d, want at least %d", g.fishCaught, catches)
+	}
+}
+
+// TestStaleCatchResetRejected comprueba que un CmdCatchDone de un lanzamiento
+// anterior no saca al juego de la pausa de la captura actual
+func TestStaleCatchResetRejected(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CatchResetDelay = Duration{time.Hour} // El reset real no llega durante la prueba
+	g := newTestGame(t, cfg)
+	stopTestSpawner(g)
+
+	// Primera captura, terminada a mano como lo haría resetAfterCatch
+	hook(t, g)
+	first := g.castSeq
+	g.sendCommand(command{cmd: CmdCatchDone, castSeq: first})
+	g.Step()
+	if s := g.State(); s != StatePlaying {
+		t.Fatalf("state after first CatchDone = %v, want %v", s, StatePlaying)
+	}
+
+	// Segunda captura: durante la pausa no se puede recoger ni relanzar
+	hook(t, g)
+	g.Reel()
+	g.Cast()
+	g.Step()
+	if s := g.State(); s != StateCaught {
+		t.Fatalf("state after Reel/Cast during catch pause = %v, want %v", s, StateCaught)
+	}
+
+	// Llega tarde el reset de la primera captura: debe rechazarse
+	g.sendCommand(command{cmd: CmdCatchDone, castSeq: first})
+	g.Step()
+	if s := g.State(); s != StateCaught {
+		t.Fatalf("stale CatchDone changed state to %v", s)
+	}
+	log := g.TransitionLog()
+	last := log[len(log)-1]
+	if last.Command != CmdCatchDone || last.From != StateCaught || last.Accepted {
+		t.Fatalf("last transition = %v, want rejected CatchDone from %v", last, StateCaught)
+	}
+	for _, tr := range log[len(log)-3 : len(log)-1] {
+		if tr.Accepted {
+			t.Errorf("transition %v during catch pause was accepted", tr)
+		}
+	}
+
+	// El reset del lanzamiento vigente sí se acepta
+	g.sendCommand(command{cmd: CmdCatchDone, castSeq: g.castSeq})
+	g.Step()
+	if s := g.State(); s != StatePlaying {
+		t.Fatalf("state after current CatchDone = %v, want %v", s, StatePlaying)
+	}
+}
+
+// TestOverlayShowsTransitions comprueba que el overlay de F3 muestra al
+// instante las últimas transiciones, incluidas las rechazadas, y que oculto
+// no formatea sus textos
+func TestOverlayShowsTransitions(t *testing.T) {
+	g := newTestGame(t, DefaultConfig())
+	stopTestSpawner(g)
+	g.toggleOverlay()
+
+	g.Reel() // Sin anzuelo en el agua: se rechaza
+	g.Step()
+	g.observeTick(0)
+
+	lines := g.metrics.lines
+	want := "  " + Transition{Frame: g.frameCount, Command: CmdReel, From: StatePlaying}.String()
+	if len(lines) == 0 || lines[len(lines)-1] != want {
+		t.Fatalf("overlay lines = %q, want last line %q", lines, want)
+	}
+
+	g.toggleOverlay()
+	g.Reel()
+	g.Step()
+	for i := 0; i < metricsSampleTicks; i++ {
+		g.observeTick(0)
+	}
+	if g.metrics.lines != nil {
+		t.Errorf("hidden overlay lines = %q, want nil", g.metrics.lines)
+	}
+}
+
+// TestPhaseChangeIsNotEscape comprueba que los peces que se van del lago por
+// el cambio de fase no cuentan como escapes
+func TestPhaseChangeIsNotEscape(t *testing.T) {
+	g := newTestGame(t, DefaultConfig())
+	stopTestSpawner(g)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if phase := g.clock.Phase(); phase == PhaseNight {
+		t.Fatalf("game starts at %v, want a phase without legendaries", phase)
+	}
+	g.fishes = append(g.fishes, NewFish(LakeCenterX, LakeCenterY, FishLegendary, g.rng, g.conditions))
+	escaped := g.bus.Published(EventFishEscaped)
+
+	g.changeConditions()
+	if n := g.countFishType(FishLegendary); n != 0 {
+		t.Fatalf("%d legendaries left in the lake by day", n)
+	}
+	if got := g.bus.Published(EventFishEscaped); got != escaped {
+		t.Errorf("phase change published %d escapes", got-escaped)
+	}
+}
+
+// TestWindyCastLandsInLake lanza desde el borde más lejano permitido con
+// viento pleno hacia afuera del lago: el anzuelo igual debe caer en el agua
+func TestWindyCastLandsInLake(t *testing.T) {
+	g := newTestGame(t, DefaultConfig())
+	stopTestSpawner(g)
+
+	g.mu.Lock()
+	g.player.X, g.player.Y = LakeCenterX, LakeCenterY+LakeRadius+60
+	g.weather.start(WeatherWind)
+	g.weather.ticksLeft = 1 << 30
+	g.weather.intensity = 1
+	g.weather.windStrength = 1
+	g.weather.windAngle = math.Pi / 2 // Hacia abajo, alejándose del lago
+	g.mu.Unlock()
+
+	g.Cast()
+	stepUntil(t, g, "bobber in the water", func() bool { return bobberActive(g) })
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if d := math.Hypot(g.bobber.X-LakeCenterX, g.bobber.Y-LakeCenterY); d > LakeRadius-10+1e-9 {
+		t.Errorf("bobber landed %.1fpx from the lake center, want at most %d", d, LakeRadius-10)
+	}
+}
+
+// stock retorna la abundancia de una especie
+func stock(g *Game, t FishType) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.ecology.Stock(t)
+}
+
+// TestEcologyCountsOnlyCatches comprueba que sólo las capturas reales
+// reducen la abundancia: un legendario que rompe la línea no la afecta
+func TestEcologyCountsOnlyCatches(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CatchResetDelay = Duration{0}
+	g := newTestGame(t, cfg)
+	stopTestSpawner(g)
+
+	before := stock(g, FishCommon)
+	hook(t, g)
+	if after := stock(g, FishCommon); after >= before {
+		t.Errorf("common stock after a catch = %v, want below %v", after, before)
+	}
+	stepUntil(t, g, "catch reset", func() bool { return g.State() == StatePlaying })
+
+	// Un legendario muerde y, recogiendo sin parar, rompe la línea
+	g.Cast()
+	stepUntil(t, g, "bobber to land", func() bool { return bobberActive(g) })
+	g.mu.Lock()
+	legendary := NewFish(g.bobber.X, g.bobber.Y, FishLegendary, g.rng, g.conditions)
+	legendary.Traits.Wariness = 0
+	g.fishes = append(g.fishes, legendary)
+	g.encounter.begin(legendary)
+	g.mu.Unlock()
+
+	before = stock(g, FishLegendary)
+	stepUntil(t, g, "strike", func() bool { return g.State() == StateFighting })
+	g.HoldReel(true)
+	stepUntil(t, g, "line break", func() bool { return g.State() == StatePlaying })
+
+	log := g.TransitionLog()
+	if last := log[len(log)-1]; last.Command != CmdLineBreak {
+		t.Fatalf("fight ended with %v, want a line break", last)
+	}
+	if after := stock(g, FishLegendary); after < before {
+		t.Errorf("legendary stock after a line break = %v, want at least %v", after, before)
+	}
+}
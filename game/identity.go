@@ -0,0 +1,163 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+)
+
+// Identificador del próximo pez creado
+var nextFishID atomic.Uint64
+
+// FishTraits son los rasgos de personalidad de un pez, de 0 a 1
+type FishTraits struct {
+	Aggression float64 // Muerde el anzuelo desde más lejos y caza más seguido
+	Wariness   float64 // Desconfía del anzuelo: hay que acercarlo más
+	Speed      float64 // Nada más rápido
+}
+
+// FishVariant es la variante de color de un pez
+type FishVariant int
+
+const (
+	VariantNormal FishVariant = iota
+	VariantDark
+	VariantGolden
+	VariantAlbino
+	numVariants
+)
+
+// String retorna el nombre de la variante (se muestra en el HUD)
+func (v FishVariant) String() string {
+	switch v {
+	case VariantNormal:
+		return "normal"
+	case VariantDark:
+		return "oscuro"
+	case VariantGolden:
+		return "dorado"
+	case VariantAlbino:
+		return "albino"
+	default:
+		return "?"
+	}
+}
+
+// Probabilidad de cada variante, tinte con que se dibuja y multiplicador de puntos
+var (
+	variantWeights = [numVariants]float64{0.70, 0.20, 0.08, 0.02}
+	variantTints   = [numVariants][3]float32{
+		VariantNormal: {1, 1, 1},
+		VariantDark:   {0.6, 0.6, 0.7},
+		VariantGolden: {1.3, 1.1, 0.5},
+		VariantAlbino: {1.6, 1.6, 1.6},
+	}
+	variantValues = [numVariants]float64{1, 1, 1.5, 2}
+)
+
+// speciesSize son la talla (cm) y la edad máxima (años) de una especie
+type speciesSize struct {
+	minSize, maxSize float64
+	maxAge           int
+}
+
+var speciesSizes = [numFishTypes]speciesSize{
+	FishCommon:    {15, 30, 4},
+	FishRare:      {25, 45, 8},
+	FishEpic:      {40, 70, 15},
+	FishLegendary: {80, 150, 40},
+}
+
+// Relación talla-peso: peso (kg) = fishWeightFactor * talla³ (cm)
+const fishWeightFactor = 0.00001
+
+// FishIdentity es lo que distingue a un pez de los demás de su especie.
+// Se genera al crear el pez y no cambia, así que se lee sin tomar f.mu.
+type FishIdentity struct {
+	ID      uint64
+	Size    float64 // Talla en cm
+	Weight  float64 // Peso en kg
+	Age     int     // Edad en años
+	Traits  FishTraits
+	Variant FishVariant
+}
+
+// newFishIdentity genera un individuo de la especie. Los peces más viejos
+// son más grandes y el peso sigue a la talla con algo de variación.
+func newFishIdentity(fishType FishType, rng *rand.Rand) FishIdentity {
+	sizes := speciesSizes[fishType]
+	age := 1 + rng.Intn(sizes.maxAge)
+	growth := math.Sqrt(float64(age) / float64(sizes.maxAge))
+	size := sizes.minSize + (sizes.maxSize-sizes.minSize)*growth*(0.9+rng.Float64()*0.1)
+
+	return FishIdentity{
+		ID:     nextFishID.Add(1),
+		Size:   size,
+		Weight: fishWeightFactor * size * size * size * (0.85 + rng.Float64()*0.3),
+		Age:    age,
+		Traits: FishTraits{
+			Aggression: rng.Float64(),
+			Wariness:   rng.Float64(),
+			Speed:      rng.Float64(),
+		},
+		Variant: randomVariant(rng),
+	}
+}
+
+// randomVariant sortea la variante de color según su probabilidad
+func randomVariant(rng *rand.Rand) FishVariant {
+	roll := rng.Float64()
+	for v := VariantNormal; v < numVariants; v++ {
+		roll -= variantWeights[v]
+		if roll < 0 {
+			return v
+		}
+	}
+	return VariantNormal
+}
+
+// sizeScale retorna cuánto más grande (o chico) es el pez que el promedio de su especie
+func (id FishIdentity) sizeScale(fishType FishType) float64 {
+	sizes := speciesSizes[fishType]
+	return id.Size / ((sizes.minSize + sizes.maxSize) / 2)
+}
+
+// speedScale retorna el multiplicador de velocidad de nado del rasgo Speed
+func (id FishIdentity) speedScale() float64 {
+	return 0.75 + 0.5*id.Traits.Speed
+}
+
+// biteScale retorna el multiplicador del radio de captura: los agresivos
+// muerden desde más lejos y los desconfiados sólo si el anzuelo está encima
+func (id FishIdentity) biteScale() float64 {
+	return max(1+0.5*id.Traits.Aggression-0.6*id.Traits.Wariness, 0.3)
+}
+
+// CatchRecord es el registro de un pez capturado
+type CatchRecord struct {
+	FishID   uint64
+	FishType FishType
+	Size     float64
+	Weight   float64
+	Age      int
+	Traits   FishTraits
+	Variant  FishVariant
+	Points   int
+}
+
+// newCatchRecord registra la captura de un pez. Los puntos base de la
+// especie se escalan por la talla respecto al promedio y por la variante.
+func newCatchRecord(fish *Fish, basePoints int) CatchRecord {
+	id := fish.FishIdentity
+	points := float64(basePoints) * id.sizeScale(fish.FishType) * variantValues[id.Variant]
+	return CatchRecord{
+		FishID:   id.ID,
+		FishType: fish.FishType,
+		Size:     id.Size,
+		Weight:   id.Weight,
+		Age:      id.Age,
+		Traits:   id.Traits,
+		Variant:  id.Variant,
+		Points:   max(int(math.Round(points)), 1),
+	}
+}
@@ -0,0 +1,354 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Duración de un encuentro legendario y espera hasta el siguiente
+const (
+	legendaryStayTicks     = 90 * ebiten.DefaultTPS  // Tiempo en el lago antes de irse
+	legendaryCooldownTicks = 180 * ebiten.DefaultTPS // Espera hasta que pueda aparecer otro
+	bannerTicks            = 3 * ebiten.DefaultTPS   // Duración de un anuncio en pantalla
+)
+
+// Pelea con un legendario: la fuerza del pez baja mientras se recoge y la
+// tensión de la línea sube; si llega a 1 la línea se rompe
+const (
+	fightStamina       = 100.0
+	fightJumpChance    = 0.02 // Probabilidad por tick de un salto en la fase de saltos
+	fightJumpTicks     = 20   // Duración de un salto
+	fightJumpTension   = 2.0  // Multiplicador de la tensión al recoger durante un salto
+	fightCircleRadius  = 12.0 // Radio del círculo que nada el pez alrededor del bobber
+	fightCircleSpeed   = 0.05 // Radianes por tick
+	fightBreakTension  = 1.0
+	fightStaminaLanded = 0.0
+)
+
+// FightPhase es la fase de la pelea con un legendario
+type FightPhase int
+
+const (
+	FightPull  FightPhase = iota // Tira fuerte: recoger de a poco
+	FightJumps                   // Salta: soltar durante los saltos
+	FightTired                   // Agotado: recoger sin parar
+	numFightPhases
+)
+
+// String retorna el nombre de la fase (se muestra durante la pelea)
+func (p FightPhase) String() string {
+	switch p {
+	case FightPull:
+		return "Tira con fuerza"
+	case FightJumps:
+		return "¡Salta!"
+	case FightTired:
+		return "Agotado"
+	default:
+		return "?"
+	}
+}
+
+// fightParams es cómo cambian la tensión y la fuerza del pez por tick en una fase
+type fightParams struct {
+	reelTension float64 // Tensión que suma recoger
+	idleTension float64 // Tensión que se libera al soltar
+	reelStamina float64 // Fuerza que pierde el pez al recoger
+	idleStamina float64 // Fuerza que recupera al soltar
+}
+
+var fightPhaseParams = [numFightPhases]fightParams{
+	FightPull:  {0.010, 0.006, 0.15, 0.05},
+	FightJumps: {0.025, 0.012, 0.20, 0.03},
+	FightTired: {0.004, 0.010, 0.25, 0.02},
+}
+
+// fightPhaseAt retorna la fase de la pelea según la fuerza que le queda al pez
+func fightPhaseAt(stamina float64) FightPhase {
+	switch {
+	case stamina > 60:
+		return FightPull
+	case stamina > 25:
+		return FightJumps
+	default:
+		return FightTired
+	}
+}
+
+// encounterStage es la etapa de un encuentro legendario
+type encounterStage int
+
+const (
+	encounterNone     encounterStage = iota
+	encounterRoaming                 // Nada por el lago
+	encounterFighting                // Mordió el anzuelo
+)
+
+// fightOutcome es el resultado de un tick de pelea
+type fightOutcome int
+
+const (
+	fightContinues fightOutcome = iota
+	fightLanded
+	fightLineBroken
+)
+
+// LegendaryEncounter es el encuentro con un pez legendario: aparece con un
+// anuncio, nada por el lago un tiempo limitado y, si muerde el anzuelo,
+// pelea en varias fases. Al terminar (capturado, línea rota o se fue) hay
+// que esperar legendaryCooldownTicks hasta el siguiente.
+// Sólo la goroutine de Update lo modifica (con g.mu tomado).
+type LegendaryEncounter struct {
+	stage     encounterStage
+	fish      *Fish
+	ticksLeft int // Tiempo hasta que se va del lago
+	cooldown  int
+
+	// Pelea
+	stamina   float64
+	tension   float64
+	jumpTicks int
+	ticks     int
+	rng       *rand.Rand
+
+	// Anuncio en pantalla
+	banner      string
+	bannerTicks int
+}
+
+// NewLegendaryEncounter crea el encuentro sin ningún legendario en el lago.
+// rng se usa para derivar el generador propio de la pelea.
+func NewLegendaryEncounter(rng *rand.Rand) LegendaryEncounter {
+	return LegendaryEncounter{rng: rand.New(rand.NewSource(rng.Int63()))}
+}
+
+// Active indica si hay un legendario en el lago o peleando
+func (e *LegendaryEncounter) Active() bool {
+	return e.stage != encounterNone
+}
+
+// Blocked indica si el spawner no debe generar legendarios: hay uno en el
+// lago o no terminó la espera. Durante la migración no hay espera.
+func (e *LegendaryEncounter) Blocked(migration bool) bool {
+	return e.Active() || (e.cooldown > 0 && !migration)
+}
+
+// Fighting indica si se está peleando con el legendario
+func (e *LegendaryEncounter) Fighting() bool {
+	return e.stage == encounterFighting
+}
+
+// Phase retorna la fase actual de la pelea
+func (e *LegendaryEncounter) Phase() FightPhase {
+	return fightPhaseAt(e.stamina)
+}
+
+// Tension retorna la tensión de la línea durante la pelea (0 a 1)
+func (e *LegendaryEncounter) Tension() float64 {
+	return e.tension
+}
+
+// announce muestra un anuncio en pantalla
+func (e *LegendaryEncounter) announce(text string) {
+	e.banner = text
+	e.bannerTicks = bannerTicks
+}
+
+// begin empieza un encuentro con un legendario que acaba de entrar al lago
+func (e *LegendaryEncounter) begin(fish *Fish) {
+	e.stage = encounterRoaming
+	e.fish = fish
+	e.ticksLeft = legendaryStayTicks
+	e.announce("¡Un pez legendario apareció en el lago!")
+}
+
+// strike empieza la pelea: el pez mordió el anzuelo
+func (e *LegendaryEncounter) strike() {
+	e.stage = encounterFighting
+	e.stamina = fightStamina
+	e.tension = 0
+	e.jumpTicks = 0
+	e.ticks = 0
+	e.announce("¡Mordió! Mantén ESPACIO para recoger, suelta si la línea se tensa")
+}
+
+// end termina el encuentro y empieza la espera hasta el siguiente
+func (e *LegendaryEncounter) end(text string) {
+	e.stage = encounterNone
+	e.fish = nil
+	e.cooldown = legendaryCooldownTicks
+	if text != "" {
+		e.announce(text)
+	}
+}
+
+// fight avanza la pelea un tick. reeling indica si el jugador está recogiendo.
+// Los peces agresivos tensan más la línea.
+func (e *LegendaryEncounter) fight(reeling bool) fightOutcome {
+	e.ticks++
+	phase := e.Phase()
+	params := fightPhaseParams[phase]
+
+	if e.jumpTicks > 0 {
+		e.jumpTicks--
+	} else if phase == FightJumps && e.rng.Float64() < fightJumpChance {
+		e.jumpTicks = fightJumpTicks
+	}
+
+	if reeling {
+		gain := params.reelTension * (0.8 + 0.4*e.fish.Traits.Aggression)
+		if e.jumpTicks > 0 {
+			gain *= fightJumpTension
+		}
+		e.tension += gain
+		e.stamina -= params.reelStamina
+	} else {
+		e.tension = max(e.tension-params.idleTension, 0)
+		e.stamina = min(e.stamina+params.idleStamina, fightStamina)
+	}
+
+	switch {
+	case e.tension >= fightBreakTension:
+		return fightLineBroken
+	case e.stamina <= fightStaminaLanded:
+		return fightLanded
+	default:
+		return fightContinues
+	}
+}
+
+// HoldReel indica si el jugador mantiene el botón de recoger durante una
+// pelea. Update lo lee del teclado; el modo headless lo llama directamente.
+func (g *Game) HoldReel(on bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.reeling = on
+}
+
+// updateEncounter avanza el encuentro legendario: el tiempo en el lago, la
+// pelea y la espera. Publica la captura FUERA del mutex, igual que
+// checkFishCollisions.
+func (g *Game) updateEncounter() {
+	g.mu.Lock()
+	e := &g.encounter
+	if e.bannerTicks > 0 {
+		e.bannerTicks--
+	}
+	if e.cooldown > 0 {
+		e.cooldown--
+	}
+
+	outcome := fightContinues
+	switch e.stage {
+	case encounterRoaming:
+		e.ticksLeft--
+		if !g.hasFish(e.fish) {
+			// Se fue por su cuenta (por ejemplo, al amanecer)
+			e.end("El pez legendario se fue del lago")
+		} else if e.ticksLeft <= 0 {
+			g.removeFish(e.fish)
+			e.fish.Stop()
+			g.bus.Publish(GameEvent{Type: EventFishEscaped, FishType: FishLegendary})
+			e.end("El pez legendario se fue del lago")
+		}
+
+	case encounterFighting:
+		outcome = e.fight(g.reeling)
+
+		// El pez nada en círculos alrededor del bobber y salpica al saltar
+		angle := float64(e.ticks) * fightCircleSpeed
+		e.fish.setPosition(g.bobber.X+math.Cos(angle)*fightCircleRadius, g.bobber.Y+math.Sin(angle)*fightCircleRadius*0.5)
+		if e.jumpTicks == fightJumpTicks {
+			g.particles.EmitCatchSplash(e.fish.Position())
+		}
+		if g.frameCount%sparkleInterval == 0 {
+			g.particles.EmitSparkle(e.fish.Position())
+		}
+	}
+	fish := e.fish
+	g.mu.Unlock()
+
+	switch outcome {
+	case fightLanded:
+		if g.dispatch(command{cmd: CmdLand}) {
+			record := newCatchRecord(fish, g.getPointsForFish(FishLegendary))
+			g.bus.Publish(GameEvent{Type: EventFishCaught, FishType: FishLegendary, Catch: record})
+		}
+	case fightLineBroken:
+		g.dispatch(command{cmd: CmdLineBreak})
+	}
+}
+
+// isEncounterFish indica si el pez es el legendario del encuentro y sigue libre
+func (g *Game) isEncounterFish(fish *Fish) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.encounter.stage == encounterRoaming && g.encounter.fish == fish
+}
+
+// releaseLegendary termina la pelea sin captura: el pez se escapa
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) releaseLegendary(text string) {
+	if !g.encounter.Fighting() {
+		return
+	}
+	g.bus.Publish(GameEvent{Type: EventFishEscaped, FishType: FishLegendary})
+	g.encounter.end(text)
+}
+
+// hasFish indica si el pez sigue en el lago
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) hasFish(fish *Fish) bool {
+	for _, f := range g.fishes {
+		if f == fish {
+			return true
+		}
+	}
+	return false
+}
+
+// removeFish saca un pez de la lista del lago
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) removeFish(fish *Fish) {
+	for i, f := range g.fishes {
+		if f == fish {
+			g.fishes = append(g.fishes[:i], g.fishes[i+1:]...)
+			return
+		}
+	}
+}
+
+// Barras de la pelea (abajo al centro)
+const (
+	fightBarX, fightBarY = ScreenWidth/2 - 100, ScreenHeight - 80
+	fightBarW, fightBarH = 200, 8
+)
+
+// drawEncounter dibuja el legendario que pelea, las barras de tensión y
+// fuerza y el anuncio vigente
+func (g *Game) drawEncounter(screen *ebiten.Image, visibility float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	e := &g.encounter
+
+	if e.Fighting() {
+		e.fish.Draw(screen, visibility)
+
+		fillRect(screen, fightBarX-10, fightBarY-22, fightBarW+20, 62, colorToast)
+		ebitenutil.DebugPrintAt(screen, e.Phase().String(), fightBarX, fightBarY-20)
+		fillRect(screen, fightBarX, fightBarY, fightBarW, fightBarH, colorPanel)
+		fillRect(screen, fightBarX, fightBarY, fightBarW*min(e.tension, 1), fightBarH, colorTension)
+		ebitenutil.DebugPrintAt(screen, "Tensión", fightBarX+fightBarW-50, fightBarY+8)
+		fillRect(screen, fightBarX, fightBarY+24, fightBarW, fightBarH, colorPanel)
+		fillRect(screen, fightBarX, fightBarY+24, fightBarW*e.stamina/fightStamina, fightBarH, colorSpecies[FishLegendary])
+	}
+
+	if e.bannerTicks > 0 {
+		alpha := min(float64(e.bannerTicks)/ebiten.DefaultTPS, 1)
+		fillRect(screen, 80, ScreenHeight-140, ScreenWidth-160, 24, fadeColor(colorToast, alpha))
+		ebitenutil.DebugPrintAt(screen, e.banner, 90, ScreenHeight-136)
+	}
+}
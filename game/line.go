@@ -0,0 +1,78 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Parámetros de la línea de pesca
+const (
+	lineSegments    = 16   // Segmentos con que se dibuja la curva
+	lineSlack       = 0.25 // Comba con la línea floja, como fracción del largo
+	lineMaxSag      = 40.0 // Comba máxima en píxeles
+	lineCatenaryK   = 1.5  // Curvatura de la catenaria (más alto = más cerrada)
+	lineTensionRate = 0.12 // Fracción de la diferencia con el objetivo que se recorre por tick
+	lineWobbleAmp   = 3.0  // Amplitud en píxeles de la vibración con un pez enganchado
+	lineWidth       = 1.0
+)
+
+// FishingLine es la línea entre la punta de la caña y el bobber.
+// Floja cuelga como una catenaria; al engancharse un pez se tensa
+// (la comba desaparece) y vibra. Sólo la goroutine de Update/Draw la usa.
+type FishingLine struct {
+	tension       float64 // 0 = floja, 1 = tensa
+	wobble        float64 // Intensidad de la vibración (0 a 1)
+	targetTension float64
+	targetWobble  float64
+	ticks         int
+}
+
+// SetTarget fija la tensión y la vibración hacia las que se mueve la línea
+func (l *FishingLine) SetTarget(tension, wobble float64) {
+	l.targetTension = tension
+	l.targetWobble = wobble
+}
+
+// Reset deja la línea floja de inmediato (al lanzar de nuevo)
+func (l *FishingLine) Reset() {
+	l.tension, l.wobble = 0, 0
+	l.targetTension, l.targetWobble = 0, 0
+}
+
+// Update acerca la tensión y la vibración a sus objetivos de forma suave
+func (l *FishingLine) Update() {
+	l.ticks++
+	l.tension += (l.targetTension - l.tension) * lineTensionRate
+	l.wobble += (l.targetWobble - l.wobble) * lineTensionRate
+}
+
+// Draw dibuja la línea de (x1, y1) (punta de la caña) a (x2, y2) (bobber)
+func (l *FishingLine) Draw(screen *ebiten.Image, x1, y1, x2, y2 float64) {
+	dx, dy := x2-x1, y2-y1
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	// Normal unitaria a la línea, para la vibración
+	nx, ny := -dy/length, dx/length
+
+	sag := math.Min(length*lineSlack, lineMaxSag) * (1 - l.tension)
+	coshK := math.Cosh(lineCatenaryK)
+
+	px, py := x1, y1
+	for i := 1; i <= lineSegments; i++ {
+		t := float64(i) / lineSegments
+
+		// Catenaria normalizada: 0 en los extremos y 1 en el centro
+		u := (math.Cosh(lineCatenaryK*(2*t-1)) - coshK) / (1 - coshK)
+
+		// Vibración: onda que recorre la línea, nula en los extremos
+		w := l.wobble * lineWobbleAmp * math.Sin(float64(l.ticks)*0.9+t*3*math.Pi) * math.Sin(math.Pi*t)
+
+		x := x1 + dx*t + nx*w
+		y := y1 + dy*t + sag*u + ny*w
+		drawLine(screen, px, py, x, y, lineWidth, colorLine)
+		px, py = x, y
+	}
+}
@@ -0,0 +1,286 @@
+package game
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Cada cuántos ticks se toma una muestra de métricas (~1 segundo)
+const metricsSampleTicks = 60
+
+// timedMutex es un sync.Mutex que mide cuánto tiempo se espera para tomarlo
+type timedMutex struct {
+	sync.Mutex
+	waitNs atomic.Int64
+	locks  atomic.Int64
+}
+
+// Lock toma el mutex acumulando el tiempo de espera si estaba ocupado
+func (m *timedMutex) Lock() {
+	if !m.TryLock() {
+		start := time.Now()
+		m.Mutex.Lock()
+		m.waitNs.Add(int64(time.Since(start)))
+	}
+	m.locks.Add(1)
+}
+
+// MetricsSnapshot es una muestra de las métricas de ejecución
+type MetricsSnapshot struct {
+	Time       time.Time
+	TPS        float64
+	FPS        float64
+	Goroutines int
+	FishByType [4]int
+	SpawnQueue int
+	CatchQueue int
+	MutexWait  time.Duration // Espera acumulada por el mutex del juego durante la muestra
+	MutexLocks int64         // Veces que se tomó el mutex durante la muestra
+	TickCost   time.Duration // Costo promedio de Step durante la muestra
+	Allocs     float64       // Reservas de memoria por tick (de todo el proceso)
+}
+
+// metricsHeader son las columnas del archivo CSV exportado
+var metricsHeader = []string{
+	"time", "tps", "fps", "goroutines",
+	"fish_common", "fish_rare", "fish_epic", "fish_legendary",
+	"spawn_queue", "catch_queue", "mutex_wait_us", "mutex_locks", "tick_cost_us",
+	"allocs_per_tick",
+}
+
+// record convierte la muestra en una fila CSV
+func (m MetricsSnapshot) record() []string {
+	return []string{
+		m.Time.Format(time.RFC3339Nano),
+		strconv.FormatFloat(m.TPS, 'f', 2, 64),
+		strconv.FormatFloat(m.FPS, 'f', 2, 64),
+		strconv.Itoa(m.Goroutines),
+		strconv.Itoa(m.FishByType[FishCommon]),
+		strconv.Itoa(m.FishByType[FishRare]),
+		strconv.Itoa(m.FishByType[FishEpic]),
+		strconv.Itoa(m.FishByType[FishLegendary]),
+		strconv.Itoa(m.SpawnQueue),
+		strconv.Itoa(m.CatchQueue),
+		strconv.FormatInt(m.MutexWait.Microseconds(), 10),
+		strconv.FormatInt(m.MutexLocks, 10),
+		strconv.FormatInt(m.TickCost.Microseconds(), 10),
+		strconv.FormatFloat(m.Allocs, 'f', 1, 64),
+	}
+}
+
+// Metrics acumula el costo de la simulación y toma muestras periódicas.
+// Sólo lo usa la goroutine de Update.
+type Metrics struct {
+	overlay bool
+
+	// Acumulado desde la última muestra
+	ticks       int
+	tickTotal   time.Duration
+	lastWait    int64
+	lastLocks   int64
+	lastMallocs uint64
+
+	last  MetricsSnapshot
+	lines []string // Textos del overlay; nil mientras está oculto
+
+	transitions      []Transition // Últimas transiciones que muestra el overlay
+	shownTransitionN int          // transitionN cuando se formatearon los textos
+
+	// Exportación a archivo
+	file   *os.File
+	writer *csv.Writer
+}
+
+// Recording indica si se están exportando las métricas a archivo
+func (m *Metrics) Recording() bool {
+	return m.writer != nil
+}
+
+// StartRecording crea el archivo CSV y escribe la cabecera
+func (m *Metrics) StartRecording(path string) error {
+	if m.Recording() {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	m.file = f
+	m.writer = csv.NewWriter(f)
+	return m.writer.Write(metricsHeader)
+}
+
+// StopRecording cierra el archivo de métricas
+func (m *Metrics) StopRecording() error {
+	if !m.Recording() {
+		return nil
+	}
+	m.writer.Flush()
+	err := m.writer.Error()
+	if cerr := m.file.Close(); err == nil {
+		err = cerr
+	}
+	m.file = nil
+	m.writer = nil
+	return err
+}
+
+// observeTick registra el costo de un tick de simulación
+func (g *Game) observeTick(cost time.Duration) {
+	m := &g.metrics
+	m.ticks++
+	m.tickTotal += cost
+
+	if m.ticks >= metricsSampleTicks {
+		m.last = g.sampleMetrics()
+		if m.Recording() {
+			if err := m.writer.Write(m.last.record()); err != nil {
+				fmt.Println("Warning: failed to write metrics:", err)
+				m.StopRecording()
+			}
+		}
+		if m.overlay {
+			g.refreshOverlay()
+		}
+	} else if m.overlay && g.transitionsChanged() {
+		// Las transiciones (sobre todo las rechazadas) se ven al instante
+		g.refreshOverlay()
+	}
+}
+
+// transitionsChanged indica si hubo transiciones desde que se formateó el overlay
+func (g *Game) transitionsChanged() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.transitionN != g.metrics.shownTransitionN
+}
+
+// sampleMetrics toma una muestra y reinicia los acumulados
+func (g *Game) sampleMetrics() MetricsSnapshot {
+	m := &g.metrics
+
+	snap := MetricsSnapshot{
+		Time:       time.Now(),
+		TPS:        ebiten.ActualTPS(),
+		FPS:        ebiten.ActualFPS(),
+		Goroutines: runtime.NumGoroutine(),
+		SpawnQueue: len(g.spawnSub.C()),
+		CatchQueue: len(g.catchSub.C()),
+	}
+	if m.ticks > 0 {
+		snap.TickCost = m.tickTotal / time.Duration(m.ticks)
+	}
+
+	g.mu.Lock()
+	for t := FishCommon; t <= FishLegendary; t++ {
+		snap.FishByType[t] = g.countFishType(t)
+	}
+	g.mu.Unlock()
+
+	wait := g.mu.waitNs.Load()
+	locks := g.mu.locks.Load()
+	snap.MutexWait = time.Duration(wait - m.lastWait)
+	snap.MutexLocks = locks - m.lastLocks
+	m.lastWait = wait
+	m.lastLocks = locks
+
+	// ReadMemStats detiene el mundo brevemente; sólo se llama una vez por muestra
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	if m.lastMallocs > 0 && m.ticks > 0 {
+		snap.Allocs = float64(mem.Mallocs-m.lastMallocs) / float64(m.ticks)
+	}
+	m.lastMallocs = mem.Mallocs
+
+	m.ticks = 0
+	m.tickTotal = 0
+	return snap
+}
+
+// toggleMetricsRecording inicia o detiene la exportación de métricas
+func (g *Game) toggleMetricsRecording() {
+	m := &g.metrics
+	if m.Recording() {
+		if err := m.StopRecording(); err != nil {
+			fmt.Println("Warning: failed to close metrics file:", err)
+		}
+	} else {
+		path := "metrics_" + time.Now().Format("20060102_150405") + ".csv"
+		if err := m.StartRecording(path); err != nil {
+			fmt.Println("Warning: failed to start metrics recording:", err)
+		}
+	}
+	if m.overlay {
+		g.refreshOverlay()
+	}
+}
+
+// toggleOverlay muestra u oculta el overlay (F3). Al ocultarlo descarta los
+// textos: drawDebugOverlay los vuelve a formatear cuando se muestre otra vez.
+func (g *Game) toggleOverlay() {
+	m := &g.metrics
+	m.overlay = !m.overlay
+	if !m.overlay {
+		m.lines = nil
+	}
+}
+
+// refreshOverlay reconstruye los textos del overlay. Mientras está visible se
+// llama al tomar una muestra, al cambiar la grabación o cuando hay transiciones
+// nuevas, no en cada frame.
+func (g *Game) refreshOverlay() {
+	m := &g.metrics
+	s := m.last
+
+	m.lines = append(m.lines[:0],
+		fmt.Sprintf("TPS: %.1f  FPS: %.1f", s.TPS, s.FPS),
+		fmt.Sprintf("Goroutines: %d", s.Goroutines),
+		fmt.Sprintf("Peces: %d C, %d R, %d E, %d L",
+			s.FishByType[FishCommon], s.FishByType[FishRare], s.FishByType[FishEpic], s.FishByType[FishLegendary]),
+		fmt.Sprintf("Cola spawn: %d/%d  captura: %d/%d",
+			s.SpawnQueue, cap(g.spawnSub.C()), s.CatchQueue, cap(g.catchSub.C())),
+		fmt.Sprintf("Espera mutex: %v (%d locks/s)", s.MutexWait, s.MutexLocks),
+		fmt.Sprintf("Costo tick: %v  allocs/tick: %.1f", s.TickCost, s.Allocs),
+	)
+	if m.Recording() {
+		m.lines = append(m.lines, "Grabando métricas (F4 para detener)")
+	} else {
+		m.lines = append(m.lines, "F4: exportar métricas a CSV")
+	}
+
+	g.mu.Lock()
+	m.transitions = g.recentTransitions(m.transitions[:0], overlayTransitions)
+	m.shownTransitionN = g.transitionN
+	g.mu.Unlock()
+	m.lines = append(m.lines, "Transiciones:")
+	for _, t := range m.transitions {
+		m.lines = append(m.lines, "  "+t.String())
+	}
+}
+
+// drawDebugOverlay dibuja las métricas de ejecución (se activa con F3)
+func (g *Game) drawDebugOverlay(screen *ebiten.Image) {
+	m := &g.metrics
+	if !m.overlay {
+		return
+	}
+	if m.lines == nil {
+		g.refreshOverlay()
+	}
+
+	x, y := 10, 248
+	fillRect(screen, float64(x), float64(y), 300, float64(16*len(m.lines)+8), colorOverlay)
+
+	for i, line := range m.lines {
+		ebitenutil.DebugPrintAt(screen, line, x+8, y+4+i*16)
+	}
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// StartMetricsServer sirve las métricas en formato de texto de Prometheus en
+// http://addr/metrics. El servidor se detiene en Shutdown.
+// Con el puerto 0 se elige uno libre; MetricsAddr retorna el asignado.
+func (g *Game) StartMetricsServer(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics server: %w", err)
+	}
+	g.metricsAddr = ln.Addr().String()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := g.WritePrometheus(w); err != nil {
+			fmt.Println("Warning: failed to write metrics:", err)
+		}
+	})
+
+	g.metricsServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := g.metricsServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Println("Warning: metrics server stopped:", err)
+		}
+	}()
+	return nil
+}
+
+// MetricsAddr retorna la dirección en la que escucha el servidor de métricas,
+// o "" si no se inició
+func (g *Game) MetricsAddr() string {
+	return g.metricsAddr
+}
+
+// stopMetricsServer detiene el servidor de métricas si está activo
+func (g *Game) stopMetricsServer(ctx context.Context) error {
+	if g.metricsServer == nil {
+		return nil
+	}
+	return g.metricsServer.Shutdown(ctx)
+}
+
+// WritePrometheus escribe las métricas del juego en formato de texto de Prometheus
+func (g *Game) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	// Leer estadísticas con mutex
+	g.mu.Lock()
+	score := g.score
+	caught := map[FishType]int{
+		FishCommon:    g.commonCount,
+		FishRare:      g.rareCount,
+		FishEpic:      g.epicCount,
+		FishLegendary: g.legendaryCount,
+	}
+	var inLake [4]int
+	for t := FishCommon; t <= FishLegendary; t++ {
+		inLake[t] = g.countFishType(t)
+	}
+	g.mu.Unlock()
+
+	spawn := g.spawnSub.Stats()
+
+	fmt.Fprintln(bw, "# HELP fishing_fish_spawned_total Peces generados por el spawner.")
+	fmt.Fprintln(bw, "# TYPE fishing_fish_spawned_total counter")
+	fmt.Fprintf(bw, "fishing_fish_spawned_total %d\n", g.bus.Published(EventFishSpawned))
+
+	fmt.Fprintln(bw, "# HELP fishing_spawn_drops_total Peces descartados porque la cola de spawn estaba llena.")
+	fmt.Fprintln(bw, "# TYPE fishing_spawn_drops_total counter")
+	fmt.Fprintf(bw, "fishing_spawn_drops_total %d\n", spawn.Dropped)
+
+	// Son los totales de la partida, no del proceso (applySave los restaura al
+	// cargar): por eso se exportan como gauge
+	fmt.Fprintln(bw, "# HELP fishing_catches_lifetime Peces capturados por rareza en toda la partida, incluida la guardada.")
+	fmt.Fprintln(bw, "# TYPE fishing_catches_lifetime gauge")
+	for t := FishCommon; t <= FishLegendary; t++ {
+		fmt.Fprintf(bw, "fishing_catches_lifetime{rarity=%q} %d\n", t.String(), caught[t])
+	}
+
+	fmt.Fprintln(bw, "# HELP fishing_fish_in_lake Peces nadando actualmente en el lago por rareza.")
+	fmt.Fprintln(bw, "# TYPE fishing_fish_in_lake gauge")
+	for t := FishCommon; t <= FishLegendary; t++ {
+		fmt.Fprintf(bw, "fishing_fish_in_lake{rarity=%q} %d\n", t.String(), inLake[t])
+	}
+
+	fmt.Fprintln(bw, "# HELP fishing_score Puntuación actual.")
+	fmt.Fprintln(bw, "# TYPE fishing_score gauge")
+	fmt.Fprintf(bw, "fishing_score %d\n", score)
+
+	fmt.Fprintln(bw, "# HELP fishing_goroutines Goroutines vivas en el proceso.")
+	fmt.Fprintln(bw, "# TYPE fishing_goroutines gauge")
+	fmt.Fprintf(bw, "fishing_goroutines %d\n", runtime.NumGoroutine())
+
+	fmt.Fprintln(bw, "# HELP fishing_events_published_total Eventos publicados en el bus por tipo.")
+	fmt.Fprintln(bw, "# TYPE fishing_events_published_total counter")
+	for t := GameEventType(0); t < numEventTypes; t++ {
+		fmt.Fprintf(bw, "fishing_events_published_total{type=%q} %d\n", t.String(), g.bus.Published(t))
+	}
+
+	stats := g.bus.Stats()
+	fmt.Fprintln(bw, "# HELP fishing_subscriber_dropped_total Eventos descartados por suscriptor.")
+	fmt.Fprintln(bw, "# TYPE fishing_subscriber_dropped_total counter")
+	for _, st := range stats {
+		fmt.Fprintf(bw, "fishing_subscriber_dropped_total{subscriber=%q} %d\n", st.Name, st.Dropped)
+	}
+	fmt.Fprintln(bw, "# HELP fishing_subscriber_queue_depth Eventos pendientes en la cola de cada suscriptor.")
+	fmt.Fprintln(bw, "# TYPE fishing_subscriber_queue_depth gauge")
+	for _, st := range stats {
+		fmt.Fprintf(bw, "fishing_subscriber_queue_depth{subscriber=%q} %d\n", st.Name, st.Depth)
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"bufio"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// scrapeMetrics pide /metrics y retorna cada serie (nombre con etiquetas) con su valor
+func scrapeMetrics(t *testing.T, addr string) map[string]float64 {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /metrics: %s", resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+
+	series := make(map[string]float64)
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		if i < 0 {
+			t.Fatalf("malformed metrics line %q", line)
+		}
+		v, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			t.Fatalf("metrics line %q: %v", line, err)
+		}
+		series[line[:i]] = v
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatalf("reading /metrics: %v", err)
+	}
+	return series
+}
+
+func TestMetricsServer(t *testing.T) {
+	g := newTestGame(t, DefaultConfig())
+	stopTestSpawner(g)
+
+	if err := g.StartMetricsServer("127.0.0.1:0"); err != nil {
+		t.Fatalf("StartMetricsServer: %v", err)
+	}
+	addr := g.MetricsAddr()
+	if addr == "" || strings.HasSuffix(addr, ":0") {
+		t.Fatalf("MetricsAddr() = %q, want the assigned port", addr)
+	}
+
+	g.applyCatch(CatchRecord{FishID: 1, FishType: FishCommon, Points: 12})
+	g.applyCatch(CatchRecord{FishID: 2, FishType: FishCommon, Points: 8})
+	g.applyCatch(CatchRecord{FishID: 3, FishType: FishEpic, Points: 70})
+
+	series := scrapeMetrics(t, addr)
+	want := map[string]float64{
+		"fishing_spawn_drops_total":                    0,
+		`fishing_catches_lifetime{rarity="common"}`:    2,
+		`fishing_catches_lifetime{rarity="rare"}`:      0,
+		`fishing_catches_lifetime{rarity="epic"}`:      1,
+		`fishing_catches_lifetime{rarity="legendary"}`: 0,
+		"fishing_score": 90,
+		`fishing_subscriber_dropped_total{subscriber="catch"}`: 0,
+	}
+	for name, v := range want {
+		got, ok := series[name]
+		if !ok {
+			t.Errorf("missing series %s", name)
+		} else if got != v {
+			t.Errorf("%s = %v, want %v", name, got, v)
+		}
+	}
+}
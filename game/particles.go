@@ -0,0 +1,191 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Capacidad del pool de partículas: si se llena, las nuevas se descartan
+const maxParticles = 512
+
+// Frecuencia de los emisores continuos (en ticks)
+const (
+	rippleInterval  = 20 // Ondas alrededor del bobber cuando un pez se acerca
+	sparkleInterval = 4  // Estela de brillos de los peces legendarios
+)
+
+// ParticleLayer indica entre qué elementos de la escena se dibuja una partícula
+type ParticleLayer uint8
+
+const (
+	LayerSurface ParticleLayer = iota // Sobre los peces y bajo el bobber (ondas, brillos)
+	LayerAir                          // Sobre el bobber y bajo el jugador (gotas)
+)
+
+// particleKind es la forma de una partícula
+type particleKind uint8
+
+const (
+	particleDot  particleKind = iota // Cuadrado pequeño (gota, brillo)
+	particleRing                     // Anillo que se expande (onda en el agua)
+)
+
+var (
+	colorDroplet = color.RGBA{190, 220, 240, 230}
+	colorRipple  = color.RGBA{170, 200, 220, 170}
+	colorSparkle = color.RGBA{255, 230, 120, 255}
+)
+
+// particle es una partícula viva del pool
+type particle struct {
+	kind    particleKind
+	layer   ParticleLayer
+	x, y    float64
+	vx, vy  float64
+	gravity float64
+	size    float64 // Lado del cuadrado o radio del anillo
+	grow    float64 // Cambio de size por tick
+	life    int     // Ticks restantes
+	maxLife int
+	clr     color.RGBA
+}
+
+// ParticleSystem es un pool fijo de partículas. Las vivas ocupan las
+// primeras n posiciones; al morir una se mueve la última a su lugar,
+// así emitir y actualizar nunca reserva memoria.
+// Sólo la goroutine de Update/Draw lo usa (con g.mu tomado).
+type ParticleSystem struct {
+	particles [maxParticles]particle
+	n         int
+	rng       *rand.Rand // Propio: con la misma semilla los efectos se repiten
+}
+
+// NewParticleSystem crea el sistema de partículas
+func NewParticleSystem(rng *rand.Rand) *ParticleSystem {
+	return &ParticleSystem{rng: rand.New(rand.NewSource(rng.Int63()))}
+}
+
+// Len retorna la cantidad de partículas vivas
+func (ps *ParticleSystem) Len() int {
+	return ps.n
+}
+
+// emit agrega una partícula si queda lugar en el pool
+func (ps *ParticleSystem) emit(p particle) {
+	if ps.n == maxParticles {
+		return
+	}
+	p.maxLife = p.life
+	ps.particles[ps.n] = p
+	ps.n++
+}
+
+// EmitCastSplash es el chapoteo del anzuelo al caer: un anillo y unas gotas
+func (ps *ParticleSystem) EmitCastSplash(x, y float64) {
+	ps.emit(particle{kind: particleRing, layer: LayerSurface, x: x, y: y, size: 4, grow: 0.5, life: 24, clr: colorSplash})
+	ps.emitDroplets(x, y, 6, 1.2)
+}
+
+// EmitCatchSplash es la salpicadura al enganchar un pez: dos anillos y muchas gotas
+func (ps *ParticleSystem) EmitCatchSplash(x, y float64) {
+	ps.emit(particle{kind: particleRing, layer: LayerSurface, x: x, y: y, size: 6, grow: 0.8, life: 30, clr: colorSplash})
+	ps.emit(particle{kind: particleRing, layer: LayerSurface, x: x, y: y, size: 2, grow: 0.5, life: 36, clr: colorRipple})
+	ps.emitDroplets(x, y, 16, 2)
+}
+
+// EmitBiteRipple es una onda suave alrededor del bobber
+func (ps *ParticleSystem) EmitBiteRipple(x, y float64) {
+	ps.emit(particle{kind: particleRing, layer: LayerSurface, x: x, y: y, size: 8, grow: 0.35, life: 40, clr: colorRipple})
+}
+
+// EmitRaindrop es la onda pequeña de una gota de lluvia sobre el lago
+func (ps *ParticleSystem) EmitRaindrop(x, y float64) {
+	ps.emit(particle{kind: particleRing, layer: LayerSurface, x: x, y: y, size: 1, grow: 0.3, life: 20, clr: colorRipple})
+}
+
+// EmitSparkle es un brillo de la estela de un pez legendario
+func (ps *ParticleSystem) EmitSparkle(x, y float64) {
+	ps.emit(particle{
+		kind:  particleDot,
+		layer: LayerSurface,
+		x:     x + (ps.rng.Float64()-0.5)*20,
+		y:     y + (ps.rng.Float64()-0.5)*14,
+		vy:    -0.2 - ps.rng.Float64()*0.3,
+		size:  2 + ps.rng.Float64()*1.5,
+		grow:  -0.05,
+		life:  20 + ps.rng.Intn(20),
+		clr:   colorSparkle,
+	})
+}
+
+// emitDroplets lanza gotas hacia arriba que caen por gravedad
+func (ps *ParticleSystem) emitDroplets(x, y float64, count int, speed float64) {
+	for i := 0; i < count; i++ {
+		angle := -math.Pi * ps.rng.Float64() // Hacia arriba
+		v := speed * (0.5 + ps.rng.Float64())
+		ps.emit(particle{
+			kind:    particleDot,
+			layer:   LayerAir,
+			x:       x,
+			y:       y,
+			vx:      math.Cos(angle) * v,
+			vy:      math.Sin(angle) * v,
+			gravity: 0.12,
+			size:    2,
+			life:    18 + ps.rng.Intn(12),
+			clr:     colorDroplet,
+		})
+	}
+}
+
+// Update avanza un tick y descarta las partículas que terminaron
+func (ps *ParticleSystem) Update() {
+	for i := 0; i < ps.n; {
+		p := &ps.particles[i]
+		p.life--
+		if p.life <= 0 {
+			ps.n--
+			ps.particles[i] = ps.particles[ps.n]
+			continue
+		}
+		p.vy += p.gravity
+		p.x += p.vx
+		p.y += p.vy
+		p.size = math.Max(p.size+p.grow, 0)
+		i++
+	}
+}
+
+// Draw dibuja las partículas de una capa
+func (ps *ParticleSystem) Draw(screen *ebiten.Image, layer ParticleLayer) {
+	for i := 0; i < ps.n; i++ {
+		p := &ps.particles[i]
+		if p.layer != layer {
+			continue
+		}
+		clr := fadeColor(p.clr, float64(p.life)/float64(p.maxLife))
+
+		switch p.kind {
+		case particleDot:
+			fillRect(screen, p.x-p.size/2, p.y-p.size/2, p.size, p.size, clr)
+		case particleRing:
+			drawRing(screen, p.x, p.y, p.size, clr)
+		}
+	}
+}
+
+// drawRing dibuja una elipse (el lago se ve en perspectiva) con segmentos
+func drawRing(screen *ebiten.Image, cx, cy, radius float64, clr color.RGBA) {
+	const segments = 12
+	px, py := cx+radius, cy
+	for i := 1; i <= segments; i++ {
+		angle := 2 * math.Pi * float64(i) / segments
+		x := cx + radius*math.Cos(angle)
+		y := cy + radius*math.Sin(angle)*0.5
+		drawLine(screen, px, py, x, y, 1.5, clr)
+		px, py = x, y
+	}
+}
@@ -1,11 +1,9 @@
 package game
 
 import (
-	"image"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 type Direction int
@@ -23,20 +21,11 @@ const (
 )
 
 type Player struct {
-	X, Y          float64
-	direction     Direction
-	moving        bool
-	frame         int
-	frameCount    int
-	walkUp        *ebiten.Image
-	walkDown      *ebiten.Image
-	walkLeft      *ebiten.Image
-	walkRight     *ebiten.Image
-	fishingSheet  *ebiten.Image
-	fishingFrames []*ebiten.Image
-	isFishing     bool
-	fishFrame     int
-	fishFCount    int
+	X, Y      float64
+	direction Direction
+	moving    bool
+	isFishing bool
+	anim      AnimationPlayer // Caminar o pescar (ver animations.json)
 }
 
 // NewPlayer crea un jugador en x,y
@@ -45,55 +34,11 @@ func NewPlayer(x, y float64) *Player {
 		X:         x,
 		Y:         y,
 		direction: DirectionDown,
+		anim:      AnimationPlayer{name: "player_walk_down"},
 	}
 }
 
-// LoadSprites carga todos los sprites del jugador
-func (p *Player) LoadSprites() error {
-	var err error
-	p.walkUp, _, err = ebitenutil.NewImageFromFile("assets/fisherman_walk_up.png")
-	if err != nil {
-		return err
-	}
-	p.walkDown, _, err = ebitenutil.NewImageFromFile("assets/fisherman_walk_down.png")
-	if err != nil {
-		return err
-	}
-	p.walkLeft, _, err = ebitenutil.NewImageFromFile("assets/fisherman_walk_left.png")
-	if err != nil {
-		return err
-	}
-	p.walkRight, _, err = ebitenutil.NewImageFromFile("assets/fisherman_walk_right.png")
-	if err != nil {
-		return err
-	}
-
-	// Cargar y dividir sprite sheet de pesca (3 frames)
-	p.fishingSheet, _, err = ebitenutil.NewImageFromFile("assets/fisherman_fishing.png")
-	if err != nil {
-		return err
-	}
-	p.loadFishingFrames()
-	return nil
-}
-
-// loadFishingFrames divide la hoja de pesca en 3 frames
-func (p *Player) loadFishingFrames() {
-	if p.fishingSheet == nil {
-		return
-	}
-	totalW := p.fishingSheet.Bounds().Dx()
-	totalH := p.fishingSheet.Bounds().Dy()
-	frameW := totalW / 3
-	frameH := totalH
-
-	for i := 0; i < 3; i++ {
-		frame := p.fishingSheet.SubImage(image.Rect(i*frameW, 0, (i+1)*frameW, frameH)).(*ebiten.Image)
-		p.fishingFrames = append(p.fishingFrames, frame)
-	}
-}
-
-// Update procesa movimiento y animaciones
+// Update procesa el movimiento con el teclado
 func (p *Player) Update() {
 	oldX, oldY := p.X, p.Y
 	p.moving = false
@@ -137,61 +82,36 @@ func (p *Player) Update() {
 	if p.Y > ScreenHeight-20 {
 		p.Y = ScreenHeight - 20
 	}
+}
 
+// Animate avanza la animación: pesca, caminata en la dirección actual o quieto
+// en el primer frame. Se llama cada tick, también mientras se pesca.
+func (p *Player) Animate() {
+	if p.isFishing {
+		p.anim.Play("player_fishing")
+		p.anim.Update()
+		return
+	}
+
+	p.anim.Play(walkAnimations[p.direction])
 	if p.moving {
-		p.frameCount++
-		if p.frameCount >= FrameDelay {
-			p.frameCount = 0
-			p.frame = (p.frame + 1) % 2
-		}
+		p.anim.Update()
 	} else {
-		p.frame = 0
+		p.anim.Reset()
 	}
+}
 
-	if p.isFishing {
-		p.fishFCount++
-		if p.fishFCount >= FrameDelay {
-			p.fishFCount = 0
-			p.fishFrame = (p.fishFrame + 1) % len(p.fishingFrames)
-		}
-	}
+// Animaciones de caminata por dirección
+var walkAnimations = [...]string{
+	DirectionDown:  "player_walk_down",
+	DirectionUp:    "player_walk_up",
+	DirectionLeft:  "player_walk_left",
+	DirectionRight: "player_walk_right",
 }
 
 // Draw dibuja al jugador
 func (p *Player) Draw(screen *ebiten.Image) {
-	if p.isFishing && len(p.fishingFrames) > 0 {
-		frame := p.fishingFrames[p.fishFrame]
-		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(-float64(frame.Bounds().Dx())/2, -float64(frame.Bounds().Dy())/2)
-		op.GeoM.Translate(p.X, p.Y)
-		screen.DrawImage(frame, op)
-		return
-	}
-
-	var sprite *ebiten.Image
-	switch p.direction {
-	case DirectionUp:
-		sprite = p.walkUp
-	case DirectionDown:
-		sprite = p.walkDown
-	case DirectionLeft:
-		sprite = p.walkLeft
-	case DirectionRight:
-		sprite = p.walkRight
-	}
-	if sprite == nil {
-		return
-	}
-	totalW := sprite.Bounds().Dx()
-	totalH := sprite.Bounds().Dy()
-	frameW := totalW / 2
-	sx := p.frame * frameW
-	sub := sprite.SubImage(image.Rect(sx, 0, sx+frameW, totalH)).(*ebiten.Image)
-
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(-float64(frameW)/2, -float64(totalH)/2)
-	op.GeoM.Translate(p.X, p.Y)
-	screen.DrawImage(sub, op)
+	p.anim.DrawAt(screen, p.X, p.Y, nil)
 }
 
 func (p *Player) isInWater() bool {
@@ -207,14 +127,23 @@ func (p *Player) IsNearWater() bool {
 	return dist >= LakeRadius-30 && dist <= LakeRadius+60
 }
 
+// RodTip retorna la punta de la caña en el frame actual de pesca.
+// ok es false si el jugador no está pescando.
+func (p *Player) RodTip() (x, y float64, ok bool) {
+	if !p.isFishing {
+		return p.X, p.Y, false
+	}
+	return p.anim.Anchor("rod_tip", p.X, p.Y)
+}
+
 func (p *Player) Cast() {
 	p.isFishing = true
-	p.fishFrame = 0
-	p.fishFCount = 0
+	p.moving = false
+	p.anim.Play("player_fishing")
+	p.anim.Reset()
 }
 
 func (p *Player) StopFishing() {
 	p.isFishing = false
-	p.fishFrame = 0
-	p.fishFCount = 0
+	p.anim.Play(walkAnimations[p.direction])
 }
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Primitivas de dibujo compartidas. Un único píxel blanco se escala y se
+// colorea para dibujar rectángulos, fondos de paneles y líneas, así Draw
+// no necesita crear imágenes nuevas en cada frame.
+var (
+	whiteImage = ebiten.NewImage(3, 3)
+
+	// Píxel central: al escalarlo no se muestrean los bordes de la imagen
+	whitePixel = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	whiteImage.Fill(color.White)
+}
+
+// fadeColor multiplica el color (premultiplicado) por alpha entre 0 y 1
+func fadeColor(clr color.RGBA, alpha float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(float64(clr.R) * alpha),
+		G: uint8(float64(clr.G) * alpha),
+		B: uint8(float64(clr.B) * alpha),
+		A: uint8(float64(clr.A) * alpha),
+	}
+}
+
+// fillRect dibuja un rectángulo relleno de color clr (admite transparencia).
+// Recibe color.RGBA y no color.Color para no reservar memoria en cada llamada.
+func fillRect(dst *ebiten.Image, x, y, w, h float64, clr color.RGBA) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(w, h)
+	op.GeoM.Translate(x, y)
+	op.ColorScale.Scale(float32(clr.R)/0xff, float32(clr.G)/0xff, float32(clr.B)/0xff, float32(clr.A)/0xff)
+	dst.DrawImage(whitePixel, op)
+}
+
+// drawLine dibuja un segmento de (x1, y1) a (x2, y2) con el grosor y color dados
+func drawLine(dst *ebiten.Image, x1, y1, x2, y2, width float64, clr color.RGBA) {
+	dx, dy := x2-x1, y2-y1
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(length, width)
+	op.GeoM.Translate(0, -width/2)
+	op.GeoM.Rotate(math.Atan2(dy, dx))
+	op.GeoM.Translate(x1, y1)
+	op.ColorScale.Scale(float32(clr.R)/0xff, float32(clr.G)/0xff, float32(clr.B)/0xff, float32(clr.A)/0xff)
+	dst.DrawImage(whitePixel, op)
+}
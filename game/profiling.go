@@ -0,0 +1,171 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+)
+
+// Profiler captura perfiles de CPU, snapshots de heap y trazas de ejecución
+// (runtime/trace) en un directorio. Sólo lo usa la goroutine de Update y main.
+type Profiler struct {
+	dir string
+
+	cpuFile   *os.File
+	traceFile *os.File
+}
+
+// NewProfiler crea un perfilador que escribe en dir.
+// El directorio se crea recién con la primera captura.
+func NewProfiler(dir string) (*Profiler, error) {
+	if dir == "" {
+		return nil, errors.New("profile dir must not be empty")
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return nil, fmt.Errorf("profile dir %s is not a directory", dir)
+	}
+	return &Profiler{dir: dir}, nil
+}
+
+// create crea el archivo de salida con la fecha y hora actual en el nombre
+func (p *Profiler) create(kind, ext string) (*os.File, error) {
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return nil, err
+	}
+	return os.Create(filepath.Join(p.dir, kind+"_"+time.Now().Format("20060102_150405")+ext))
+}
+
+// Capturing indica si hay una captura de CPU/traza en curso
+func (p *Profiler) Capturing() bool {
+	return p.cpuFile != nil || p.traceFile != nil
+}
+
+// StartCPU inicia el perfil de CPU
+func (p *Profiler) StartCPU() error {
+	if p.cpuFile != nil {
+		return nil
+	}
+	f, err := p.create("cpu", ".pprof")
+	if err != nil {
+		return err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	p.cpuFile = f
+	return nil
+}
+
+// StartTrace inicia la traza de ejecución
+func (p *Profiler) StartTrace() error {
+	if p.traceFile != nil {
+		return nil
+	}
+	f, err := p.create("trace", ".out")
+	if err != nil {
+		return err
+	}
+	if err := trace.Start(f); err != nil {
+		f.Close()
+		return err
+	}
+	p.traceFile = f
+	return nil
+}
+
+// Start inicia el perfil de CPU y la traza a la vez
+func (p *Profiler) Start() error {
+	if err := p.StartCPU(); err != nil {
+		return fmt.Errorf("cpu profile: %w", err)
+	}
+	if err := p.StartTrace(); err != nil {
+		return fmt.Errorf("trace: %w", err)
+	}
+	return nil
+}
+
+// Stop detiene las capturas en curso y cierra los archivos
+func (p *Profiler) Stop() error {
+	var errs []error
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		errs = append(errs, p.cpuFile.Close())
+		p.cpuFile = nil
+	}
+	if p.traceFile != nil {
+		trace.Stop()
+		errs = append(errs, p.traceFile.Close())
+		p.traceFile = nil
+	}
+	return errors.Join(errs...)
+}
+
+// WriteHeap escribe un snapshot del heap (después de un GC para datos actualizados)
+func (p *Profiler) WriteHeap() (string, error) {
+	f, err := p.create("heap", ".pprof")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// SetProfiler asigna el perfilador usado por las teclas F5 (CPU + traza) y F6 (heap)
+func (g *Game) SetProfiler(p *Profiler) {
+	g.profiler = p
+}
+
+// toggleProfiling inicia o detiene la captura de CPU y traza (F5)
+func (g *Game) toggleProfiling() {
+	if g.profiler == nil {
+		return
+	}
+	if g.profiler.Capturing() {
+		if err := g.profiler.Stop(); err != nil {
+			fmt.Println("Warning: failed to stop profiling:", err)
+		}
+		fmt.Println("Profiling stopped")
+		return
+	}
+	if err := g.profiler.Start(); err != nil {
+		fmt.Println("Warning: failed to start profiling:", err)
+		g.profiler.Stop()
+		return
+	}
+	fmt.Println("Profiling started in", g.profiler.dir)
+}
+
+// writeHeapSnapshot guarda un snapshot del heap (F6)
+func (g *Game) writeHeapSnapshot() {
+	if g.profiler == nil {
+		return
+	}
+	path, err := g.profiler.WriteHeap()
+	if err != nil {
+		fmt.Println("Warning: failed to write heap profile:", err)
+		return
+	}
+	fmt.Println("Heap profile written to", path)
+}
+
+// labelGoroutine etiqueta la goroutine actual para pprof (role=...) y retorna
+// el contexto con las etiquetas, que también se usa para las regiones de traza.
+// Así las goroutines de peces y del productor-consumidor se distinguen en
+// los perfiles y en la traza de ejecución.
+func labelGoroutine(ctx context.Context, role string) context.Context {
+	ctx = pprof.WithLabels(ctx, pprof.Labels("role", role))
+	pprof.SetGoroutineLabels(ctx)
+	return ctx
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Archivo de guardado de la partida
+const saveFile = "savegame.json"
+
+// SaveData es el estado de la partida que se persiste entre sesiones
+type SaveData struct {
+	Score          int `json:"score"`
+	FishCaught     int `json:"fish_caught"`
+	CommonCount    int `json:"common_count"`
+	RareCount      int `json:"rare_count"`
+	EpicCount      int `json:"epic_count"`
+	LegendaryCount int `json:"legendary_count"`
+
+	// Reloj del juego (nil en partidas guardadas antes de existir el reloj)
+	Clock *ClockSave `json:"clock,omitempty"`
+
+	// Abundancia de cada especie, en el orden de FishType (vacío en partidas
+	// guardadas antes de existir la ecología)
+	Stocks []float64 `json:"stocks,omitempty"`
+}
+
+// ClockSave es la hora del juego guardada
+type ClockSave struct {
+	Day     int     `json:"day"`
+	Minutes float64 `json:"minutes"`
+}
+
+// LoadSave lee la partida guardada. Si el archivo no existe (o path está vacío)
+// retorna ok=false sin error.
+func LoadSave(path string) (data SaveData, ok bool, err error) {
+	if path == "" {
+		return SaveData{}, false, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return SaveData{}, false, nil
+	}
+	if err != nil {
+		return SaveData{}, false, err
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return SaveData{}, false, fmt.Errorf("invalid save file %s: %w", path, err)
+	}
+	return data, true, nil
+}
+
+// WriteSave guarda la partida en disco. Escribe primero a un archivo temporal
+// para no dejar un guardado corrupto si el proceso se interrumpe.
+func WriteSave(path string, data SaveData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// snapshotSave copia las estadísticas actuales del juego
+func (g *Game) snapshotSave() SaveData {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	data := SaveData{
+		Score:          g.score,
+		FishCaught:     g.fishCaught,
+		CommonCount:    g.commonCount,
+		RareCount:      g.rareCount,
+		EpicCount:      g.epicCount,
+		LegendaryCount: g.legendaryCount,
+		Clock:          &ClockSave{Day: g.clock.Day(), Minutes: g.clock.Minutes()},
+	}
+	for t := FishCommon; t < numFishTypes; t++ {
+		data.Stocks = append(data.Stocks, g.ecology.Stock(t))
+	}
+	return data
+}
+
+// applySave restaura las estadísticas desde una partida guardada
+func (g *Game) applySave(data SaveData) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.score = data.Score
+	g.fishCaught = data.FishCaught
+	g.commonCount = data.CommonCount
+	g.rareCount = data.RareCount
+	g.epicCount = data.EpicCount
+	g.legendaryCount = data.LegendaryCount
+	if c := data.Clock; c != nil && c.Minutes >= 0 && c.Minutes < minutesPerDay {
+		g.clock = NewGameClock(g.cfg.DayLength.Duration, c.Day, c.Minutes)
+	}
+	if len(data.Stocks) == numFishTypes {
+		for t := FishCommon; t < numFishTypes; t++ {
+			g.ecology.SetStock(t, data.Stocks[t])
+			g.conditions.setStock(t, g.ecology.Stock(t))
+		}
+	}
+}
+
+// Autosave guarda la partida y el progreso de logros
+func (g *Game) Autosave() error {
+	if g.savePath != "" {
+		if err := WriteSave(g.savePath, g.snapshotSave()); err != nil {
+			return fmt.Errorf("error saving game: %w", err)
+		}
+	}
+	if err := g.achievements.Save(); err != nil {
+		return fmt.Errorf("error saving achievements: %w", err)
+	}
+	return nil
+}
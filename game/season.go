@@ -0,0 +1,79 @@
+package game
+
+// Días de juego que dura cada estación
+const daysPerSeason = 3
+
+// Season es la estación del calendario del juego
+type Season int
+
+const (
+	SeasonSpring Season = iota
+	SeasonSummer
+	SeasonAutumn
+	SeasonWinter
+	numSeasons
+)
+
+// String retorna el nombre de la estación (se muestra en el HUD)
+func (s Season) String() string {
+	switch s {
+	case SeasonSpring:
+		return "Primavera"
+	case SeasonSummer:
+		return "Verano"
+	case SeasonAutumn:
+		return "Otoño"
+	case SeasonWinter:
+		return "Invierno"
+	default:
+		return "?"
+	}
+}
+
+// Actividad de cada especie por estación: multiplica su probabilidad de
+// aparecer, igual que la fase del día. En invierno los raros no salen.
+var seasonSpawnWeights = [numSeasons][numFishTypes]float64{
+	SeasonSpring: {1.3, 1.0, 0.8, 1.0},
+	SeasonSummer: {1.0, 1.3, 1.0, 1.0},
+	SeasonAutumn: {0.9, 1.0, 1.4, 1.0},
+	SeasonWinter: {1.0, 0, 0.6, 1.0},
+}
+
+// Multiplicador del límite de peces por especie (MaxCommonFish, etc.) en cada estación
+var seasonCapScales = [numSeasons][numFishTypes]float64{
+	SeasonSpring: {1.5, 1.0, 1.0, 1.0},
+	SeasonSummer: {1.0, 1.5, 1.0, 1.0},
+	SeasonAutumn: {0.8, 1.0, 1.5, 1.0},
+	SeasonWinter: {0.6, 0, 0.5, 1.0},
+}
+
+// Migración legendaria: la última noche del otoño los legendarios cruzan el
+// lago, salen más seguido y sin espera entre un encuentro y el siguiente
+const (
+	migrationSeason      = SeasonAutumn
+	migrationSpawnWeight = 4.0
+)
+
+// seasonAt retorna la estación de un día y el día dentro de la estación (desde 1)
+func seasonAt(day int) (Season, int) {
+	return Season(day / daysPerSeason % int(numSeasons)), day%daysPerSeason + 1
+}
+
+// Season retorna la estación actual. Se deriva del día del reloj, así que
+// avanza entre sesiones junto con la partida guardada.
+func (c *GameClock) Season() Season {
+	season, _ := seasonAt(c.day)
+	return season
+}
+
+// SeasonDay retorna el día dentro de la estación actual (de 1 a daysPerSeason)
+func (c *GameClock) SeasonDay() int {
+	_, day := seasonAt(c.day)
+	return day
+}
+
+// Migration indica si está en curso la migración legendaria
+func (c *GameClock) Migration() bool {
+	season, day := seasonAt(c.day)
+	return season == migrationSeason && day == daysPerSeason && c.Phase() == PhaseNight
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// Tiempo máximo por defecto para el cierre ordenado
+const DefaultShutdownTimeout = 5 * time.Second
+
+// Tiempo que se espera a que las goroutines que ya llamaron wg.Done
+// terminen de salir antes de comparar con baseGoroutines
+const goroutineSettleTime = 200 * time.Millisecond
+
+// ErrShutdownTimeout indica que alguna goroutine no terminó a tiempo
+var ErrShutdownTimeout = errors.New("shutdown timed out")
+
+// Shutdown cierra el juego de forma ordenada:
+//  0. Detiene el servidor de métricas (si se inició)
+//  1. Detiene el spawner (no se generan más peces)
+//  2. Cierra el bus y espera a que catchProcessor procese las capturas pendientes
+//  3. Detiene todos los peces y cancela el resto de goroutines
+//  4. Guarda la partida (autosave)
+//  5. Espera a todas las goroutines con un límite de tiempo
+//  6. Compara las goroutines vivas con las que había al crear el juego
+//
+// El bus se cierra ANTES de cancelar el contexto: catchProcessor sigue
+// leyendo, así que los publicadores bloqueados en la cola de capturas se
+// liberan sin descartar nada. Si el tiempo se agota en cualquier paso se
+// considera una fuga de goroutines: se vuelcan las pilas por consola y se
+// retorna ErrShutdownTimeout.
+// Es seguro llamarlo más de una vez; sólo la primera llamada tiene efecto.
+func (g *Game) Shutdown(timeout time.Duration) error {
+	g.shutdownOnce.Do(func() {
+		g.shutdownErr = g.shutdown(timeout)
+	})
+	return g.shutdownErr
+}
+
+func (g *Game) shutdown(timeout time.Duration) error {
+	deadline := time.After(timeout)
+	stopBy := time.Now().Add(timeout)
+	defer g.cancel() // Pase lo que pase, no dejar goroutines sin señal de cierre
+
+	// 0. Dejar de servir métricas (no deben leer estado a medio cerrar)
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), timeout)
+	defer cancelStop()
+	if err := g.stopMetricsServer(stopCtx); err != nil {
+		fmt.Println("Warning: metrics server shutdown:", err)
+	}
+
+	// 1. Detener el productor de peces
+	g.stopSpawner()
+	select {
+	case <-g.spawnerDone:
+	case <-deadline:
+		return g.reportLeak("fishSpawner")
+	}
+
+	// 2. Cerrar el bus: catchProcessor vacía su cola y termina al ver el canal cerrado.
+	// Close espera a los publicadores bloqueados en la cola de capturas, que se
+	// liberan a medida que catchProcessor consume; por eso también tiene límite.
+	closed := make(chan struct{})
+	go func() {
+		g.bus.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-deadline:
+		return g.reportLeak("bus close")
+	}
+	select {
+	case <-g.catchesDone:
+	case <-deadline:
+		return g.reportLeak("catchProcessor")
+	}
+
+	// 3. Detener los peces y el resto de goroutines
+	g.mu.Lock()
+	for _, fish := range g.fishes {
+		fish.Stop()
+	}
+	g.mu.Unlock()
+	g.cancel()
+
+	// 4. Guardar la partida y cerrar el archivo de métricas si se estaba grabando
+	saveErr := g.Autosave()
+	if err := g.metrics.StopRecording(); err != nil {
+		saveErr = errors.Join(saveErr, err)
+	}
+
+	// 5. Esperar a que todas las goroutines terminen
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-deadline:
+		return errors.Join(saveErr, g.reportLeak("goroutines"))
+	}
+
+	// 6. wg.Wait sólo garantiza que se llamó a Done: dar un momento a que
+	// las goroutines salgan y avisar si quedaron más que al empezar
+	g.checkGoroutineBaseline(stopBy)
+
+	return saveErr
+}
+
+// checkGoroutineBaseline espera (hasta goroutineSettleTime o stopBy) a que
+// las goroutines vivas vuelvan a baseGoroutines y avisa si no lo hacen.
+// Sólo avisa: con ventana, ebiten puede mantener goroutines propias vivas.
+func (g *Game) checkGoroutineBaseline(stopBy time.Time) {
+	settleBy := time.Now().Add(goroutineSettleTime)
+	if settleBy.After(stopBy) {
+		settleBy = stopBy
+	}
+	n := runtime.NumGoroutine()
+	for n > g.baseGoroutines && time.Now().Before(settleBy) {
+		time.Sleep(5 * time.Millisecond)
+		n = runtime.NumGoroutine()
+	}
+	if n > g.baseGoroutines {
+		fmt.Printf("Warning: %d goroutines alive after shutdown (baseline: %d)\n", n, g.baseGoroutines)
+	}
+}
+
+// reportLeak vuelca las pilas de las goroutines vivas y retorna el error de timeout
+func (g *Game) reportLeak(stage string) error {
+	fmt.Fprintf(os.Stderr, "Shutdown: %s did not finish (goroutines: %d, baseline: %d)\n",
+		stage, runtime.NumGoroutine(), g.baseGoroutines)
+	pprof.Lookup("goroutine").WriteTo(os.Stderr, 1)
+	return fmt.Errorf("%w waiting for %s", ErrShutdownTimeout, stage)
+}
@@ -1,12 +1,13 @@
 package game
 
 import (
+	"fmt"
 	"math"
-	"math/rand"
+	"runtime/trace"
 	"time"
 )
 
-// Límites de peces por tipo en el lago
+// Límites de peces por tipo en el lago (valores por defecto de Config)
 const (
 	MaxCommonFish    = 10
 	MaxRareFish      = 6
@@ -14,138 +15,282 @@ const (
 	MaxLegendaryFish = 1
 )
 
+// Cada cuánto decide el director si genera peces
+const spawnDirectorTick = 250 * time.Millisecond
+
+// Ajustes del ritmo de aparición
+const (
+	spawnFillBoost    = 1.0 // Con el lago vacío los peces salen el doble de rápido que casi lleno
+	spawnFishingBoost = 1.5 // Con el anzuelo en el agua los peces se acercan más seguido
+)
+
+// Probabilidades base de cada tipo de pez:
+// Común:      60%
+// Raro:       25%
+// Épico:      12%
+// Legendario:  3%
+var baseSpawnWeights = [numFishTypes]float64{0.60, 0.25, 0.12, 0.03}
+
+// SpawnZone es una zona circular del lago donde aparecen peces
+type SpawnZone struct {
+	X       float64  `json:"x"` // Centro, relativo al centro del lago
+	Y       float64  `json:"y"`
+	Radius  float64  `json:"radius"`
+	Weight  float64  `json:"weight"`  // Peso al elegir entre zonas (0 = 1)
+	Species []string `json:"species"` // Especies que aparecen aquí (vacío = todas)
+}
+
+// allows indica si la zona admite una especie
+func (z SpawnZone) allows(t FishType) bool {
+	if len(z.Species) == 0 {
+		return true
+	}
+	for _, name := range z.Species {
+		if name == t.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// weight retorna el peso de la zona al elegir dónde aparece un pez
+func (z SpawnZone) weight() float64 {
+	if z.Weight == 0 {
+		return 1
+	}
+	return z.Weight
+}
+
+// validate verifica que la zona esté dentro del lago y nombre especies conocidas
+func (z SpawnZone) validate() error {
+	if z.Radius <= 0 {
+		return fmt.Errorf("radius %.1f: must be positive", z.Radius)
+	}
+	if z.Weight < 0 {
+		return fmt.Errorf("weight %.1f: must not be negative", z.Weight)
+	}
+	if math.Hypot(z.X, z.Y) >= LakeRadius-20 {
+		return fmt.Errorf("center (%.1f, %.1f): must be inside the lake", z.X, z.Y)
+	}
+	for _, name := range z.Species {
+		known := false
+		for t := FishCommon; t < numFishTypes; t++ {
+			known = known || name == t.String()
+		}
+		if !known {
+			return fmt.Errorf("species %q: unknown fish type", name)
+		}
+	}
+	return nil
+}
+
 // ============================================================================
 // PRODUCTOR: fishSpawner
 // ============================================================================
-// Esta goroutine genera nuevos peces periódicamente y los envía al canal
-// Respeta los límites por tipo para evitar saturación
+// Esta goroutine es el director de apariciones: cada spawnDirectorTick decide
+// qué especies generan un pez y los publica en el bus.
+// Cada especie con espacio tiene su propio sorteo, con probabilidad
+// proporcional a su peso, así una especie llena no gasta los sorteos de las
+// demás y entre las que tienen espacio se mantienen las proporciones
+// 60/25/12/3. El ritmo común sube con el lago vacío y mientras se pesca.
 func (g *Game) fishSpawner() {
 	defer g.wg.Done()
+	defer close(g.spawnerDone)
+	ctx := labelGoroutine(g.spawnCtx, "spawner")
 
-	ticker := time.NewTicker(3 * time.Second) // Intentar generar pez cada 3 segundos
+	ticker := time.NewTicker(spawnDirectorTick)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-g.ctx.Done():
+		case <-ctx.Done():
 			// El juego se está cerrando
 			return
 
 		case <-ticker.C:
-			// Decidir qué tipo de pez crear (probabilidades normales)
-			fishType := g.randomFishType()
+			region := trace.StartRegion(ctx, "spawner.tick")
 
-			// Verificar si hay espacio para este tipo de pez
-			if g.canSpawnFish(fishType) {
-				// Crear el pez
+			spawns := g.rollSpawns(spawnDirectorTick)
+			for fishType := FishCommon; fishType < numFishTypes; fishType++ {
+				if !spawns[fishType] {
+					continue
+				}
 				fish := g.spawnFishOfType(fishType)
 
-				// Enviar al canal (no bloqueante)
-				select {
-				case g.spawnChan <- fish:
-					// Pez enviado exitosamente al canal
-				default:
-					// Canal lleno, descartar este pez
-				}
+				// Publicar en el bus (si la cola está llena el pez se descarta
+				// y queda contado en las métricas del suscriptor)
+				g.bus.Publish(GameEvent{Type: EventFishSpawned, Fish: fish, FishType: fishType})
+				trace.Log(ctx, "spawn", fishType.String())
 			}
-			// Si no hay espacio, simplemente no se crea nada este tick
+			region.End()
 		}
 	}
 }
 
-// canSpawnFish verifica si se puede crear un pez de este tipo
-// Cuenta cuántos hay actualmente y compara con el límite
-func (g *Game) canSpawnFish(fishType FishType) bool {
+// rollSpawns decide qué especies generan un pez en un paso del director.
+// Con el lago vacío y sin pescar, en promedio sale un pez por spawn_interval.
+func (g *Game) rollSpawns(step time.Duration) (spawns [numFishTypes]bool) {
+	// Contar peces por tipo en el lago
+	var counts [numFishTypes]int
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	for _, fish := range g.fishes {
+		counts[fish.FishType]++
+	}
+	fishing := g.bobber.active
+	legendaryBlocked := g.encounter.Blocked(g.clock.Migration())
+	g.mu.Unlock()
 
-	// Contar peces de este tipo en el lago
-	count := g.countFishType(fishType)
+	// Población objetivo de cada especie: su límite ajustado a la estación
+	var caps [numFishTypes]int
+	population, target := 0, 0
+	for t := FishCommon; t < numFishTypes; t++ {
+		caps[t] = g.conditions.MaxFish(t, g.cfg.maxFish(t))
+		if t == FishLegendary && legendaryBlocked {
+			// Un legendario a la vez y con espera entre encuentros (ver legendary.go)
+			caps[t] = 0
+		}
+		population += min(counts[t], caps[t])
+		target += caps[t]
+	}
+	if population >= target {
+		return spawns
+	}
 
-	// Verificar contra el límite correspondiente
-	switch fishType {
-	case FishCommon:
-		return count < MaxCommonFish
-	case FishRare:
-		return count < MaxRareFish
-	case FishEpic:
-		return count < MaxEpicFish
-	case FishLegendary:
-		return count < MaxLegendaryFish
-	default:
-		return false
+	// Ritmo común a todas las especies, para no alterar sus proporciones.
+	// spawn_interval es el ritmo con el lago vacío; al llenarse baja hasta
+	// 1/(1+spawnFillBoost) de ese ritmo.
+	deficit := float64(target-population) / float64(target)
+	rate := float64(step) / float64(g.cfg.SpawnInterval.Duration)
+	rate *= (1 + spawnFillBoost*deficit) / (1 + spawnFillBoost)
+	if fishing {
+		rate *= spawnFishingBoost
 	}
+
+	// Los pesos ya incluyen la fase del día, el clima y la estación
+	// (ver clock.go, weather.go y season.go): una especie inactiva nunca sale
+	for t := FishCommon; t < numFishTypes; t++ {
+		if counts[t] < caps[t] && g.rng.Float64() < rate*g.conditions.SpawnWeight(t) {
+			spawns[t] = true
+		}
+	}
+	return spawns
 }
 
-// spawnFishOfType crea un pez del tipo especificado en una posición aleatoria
+// spawnFishOfType crea un pez del tipo especificado en una posición aleatoria,
+// dentro de una de las zonas configuradas que admiten la especie o, si no
+// hay ninguna, en cualquier parte del lago
 func (g *Game) spawnFishOfType(fishType FishType) *Fish {
-	// Generar posición aleatoria dentro del lago (círculo)
-	angle := rand.Float64() * 2 * math.Pi
-	radius := rand.Float64() * (LakeRadius - 20) // Un poco dentro del borde
+	cx, cy, maxRadius := 0.0, 0.0, float64(LakeRadius-20) // Un poco dentro del borde
+	if zone, ok := g.pickSpawnZone(fishType); ok {
+		cx, cy, maxRadius = zone.X, zone.Y, zone.Radius
+	}
 
-	x := LakeCenterX + radius*math.Cos(angle)
-	y := LakeCenterY + radius*math.Sin(angle)
+	// Generar posición aleatoria dentro de la zona (círculo)
+	angle := g.rng.Float64() * 2 * math.Pi
+	radius := g.rng.Float64() * maxRadius
 
-	return NewFish(x, y, fishType)
+	x := cx + radius*math.Cos(angle)
+	y := cy + radius*math.Sin(angle)
+
+	// Las zonas pueden salirse del lago: acercar el pez al centro
+	if dist := math.Hypot(x, y); dist > LakeRadius-20 {
+		x *= (LakeRadius - 20) / dist
+		y *= (LakeRadius - 20) / dist
+	}
+
+	return NewFish(LakeCenterX+x, LakeCenterY+y, fishType, g.rng, g.conditions)
 }
 
-// randomFishType determina el tipo de pez basado en probabilidades
-// IMPORTANTE: Las probabilidades NUNCA cambian, son siempre las mismas
-func (g *Game) randomFishType() FishType {
-	roll := rand.Float64()
-
-	// Probabilidades fijas:
-	// Común:      60%
-	// Raro:       25%
-	// Épico:      12%
-	// Legendario:  3%
-
-	switch {
-	case roll < 0.60:
-		return FishCommon
-	case roll < 0.85:
-		return FishRare
-	case roll < 0.97:
-		return FishEpic
-	default:
-		return FishLegendary
+// pickSpawnZone elige al azar, según su peso, una zona que admita la especie
+func (g *Game) pickSpawnZone(fishType FishType) (SpawnZone, bool) {
+	total := 0.0
+	for _, zone := range g.cfg.SpawnZones {
+		if zone.allows(fishType) {
+			total += zone.weight()
+		}
+	}
+	if total == 0 {
+		return SpawnZone{}, false
+	}
+
+	roll := g.rng.Float64() * total
+	for _, zone := range g.cfg.SpawnZones {
+		if !zone.allows(fishType) {
+			continue
+		}
+		roll -= zone.weight()
+		if roll < 0 {
+			return zone, true
+		}
 	}
+	return SpawnZone{}, false
 }
 
 // ============================================================================
 // CONSUMIDOR: catchProcessor
 // ============================================================================
-// Esta goroutine lee del canal de capturas y actualiza la puntuación
+// Esta goroutine lee las capturas del bus y actualiza la puntuación.
+// La cola de capturas usa OverflowBlock, así que ninguna captura se pierde:
+// al cerrar el juego se procesan las que aún estén en la cola.
 func (g *Game) catchProcessor() {
 	defer g.wg.Done()
+	defer close(g.catchesDone)
+	ctx := labelGoroutine(g.ctx, "catchProcessor")
 
 	for {
 		select {
 		case <-g.ctx.Done():
+			g.drainCatches()
 			return
 
-		case fishType := <-g.catchChan:
-			// Calcular puntos por el pez capturado
-			points := g.getPointsForFish(fishType)
-
-			// Actualizar estadísticas (con mutex para thread-safety)
-			g.mu.Lock()
-			g.score += points
-			g.fishCaught++
-
-			// Actualizar contador específico del tipo de pez
-			switch fishType {
-			case FishCommon:
-				g.commonCount++
-			case FishRare:
-				g.rareCount++
-			case FishEpic:
-				g.epicCount++
-			case FishLegendary:
-				g.legendaryCount++
+		case ev, ok := <-g.catchSub.C():
+			if !ok {
+				return
 			}
+			region := trace.StartRegion(ctx, "catch.apply")
+			g.applyCatch(ev.Catch)
+			region.End()
+		}
+	}
+}
 
-			g.mu.Unlock()
+// drainCatches procesa las capturas que quedaron en la cola sin bloquear
+func (g *Game) drainCatches() {
+	for {
+		select {
+		case ev, ok := <-g.catchSub.C():
+			if !ok {
+				return
+			}
+			g.applyCatch(ev.Catch)
+		default:
+			return
 		}
 	}
-}
\ No newline at end of file
+}
+
+// applyCatch suma los puntos y actualiza las estadísticas de una captura
+func (g *Game) applyCatch(record CatchRecord) {
+	fishType := record.FishType
+
+	// Actualizar estadísticas (con mutex para thread-safety)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Los puntos ya vienen calculados según la talla y la variante del pez
+	g.score += record.Points
+	g.fishCaught++
+	g.lastCatch = record
+
+	// Actualizar contador específico del tipo de pez
+	switch fishType {
+	case FishCommon:
+		g.commonCount++
+	case FishRare:
+		g.rareCount++
+	case FishEpic:
+		g.epicCount++
+	case FishLegendary:
+		g.legendaryCount++
+	}
+}
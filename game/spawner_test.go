@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// floodCatches publica n capturas desde otra goroutine y retorna la suma de sus puntos
+func floodCatches(g *Game, n int) (points int, done <-chan struct{}) {
+	records := make([]CatchRecord, n)
+	for i := range records {
+		records[i] = CatchRecord{FishID: uint64(i + 1), FishType: FishType(i % int(numFishTypes)), Points: 1 + i%37}
+		points += records[i].Points
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for _, record := range records {
+			g.bus.Publish(GameEvent{Type: EventFishCaught, FishType: record.FishType, Catch: record})
+		}
+	}()
+	return points, finished
+}
+
+// waitCatchQueueFull espera a que la cola de capturas (de 10) se llene
+func waitCatchQueueFull(t *testing.T, g *Game) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s := subStats(t, g.bus, "catch")
+		if s.Depth == s.Capacity {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("catch queue never filled: %+v", s)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestCatchFloodNoLoss llena la cola de capturas, bloquea al publicador y
+// comprueba que al cerrar no se perdió ninguna captura ni ningún punto
+func TestCatchFloodNoLoss(t *testing.T) {
+	const n = 500
+
+	g := newTestGame(t, DefaultConfig())
+	stopTestSpawner(g)
+
+	// Con g.mu tomado catchProcessor no puede aplicar capturas y la cola se llena
+	g.mu.Lock()
+	points, done := floodCatches(g, n)
+	waitCatchQueueFull(t, g)
+	g.mu.Unlock()
+
+	<-done
+	if err := g.Shutdown(DefaultShutdownTimeout); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.fishCaught != n {
+		t.Errorf("fishCaught = %d, want %d", g.fishCaught, n)
+	}
+	if g.score != points {
+		t.Errorf("score = %d, want %d", g.score, points)
+	}
+	if s := subStats(t, g.bus, "catch"); s.Dropped != 0 {
+		t.Errorf("catch queue dropped %d events", s.Dropped)
+	}
+}
+
+// TestCatchFloodDuringShutdown cierra el juego con el publicador bloqueado:
+// las capturas ya entregadas se aplican y las publicadas después de cerrar
+// el bus se cuentan como descartadas, así que no se pierde ninguna sin aviso
+func TestCatchFloodDuringShutdown(t *testing.T) {
+	const n = 500
+
+	g := newTestGame(t, DefaultConfig())
+	stopTestSpawner(g)
+
+	g.mu.Lock()
+	_, done := floodCatches(g, n)
+	waitCatchQueueFull(t, g)
+	g.mu.Unlock()
+
+	if err := g.Shutdown(DefaultShutdownTimeout); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	<-done
+
+	g.mu.Lock()
+	caught := g.fishCaught
+	g.mu.Unlock()
+	s := subStats(t, g.bus, "catch")
+	if int64(caught) != s.Delivered {
+		t.Errorf("fishCaught = %d, delivered = %d", caught, s.Delivered)
+	}
+	if s.Delivered+s.Dropped != n {
+		t.Errorf("delivered %d + dropped %d, want %d", s.Delivered, s.Dropped, n)
+	}
+}
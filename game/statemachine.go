@@ -0,0 +1,255 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// Cantidad de transiciones que se guardan para depuración y que se
+// muestran en el overlay de F3
+const (
+	transitionLogSize  = 64
+	overlayTransitions = 4
+)
+
+// Command es una petición de cambio de estado
+type Command int
+
+const (
+	CmdCast      Command = iota // Lanzar el anzuelo
+	CmdReel                     // Recoger el anzuelo sin captura
+	CmdHook                     // El anzuelo tocó un pez
+	CmdCatchDone                // Terminó la pausa después de la captura
+	CmdStrike                   // Un legendario mordió: empieza la pelea
+	CmdLand                     // El legendario se rindió
+	CmdLineBreak                // La línea se rompió durante la pelea
+)
+
+// String retorna el nombre del comando (para el log de transiciones)
+func (c Command) String() string {
+	switch c {
+	case CmdCast:
+		return "Cast"
+	case CmdReel:
+		return "Reel"
+	case CmdHook:
+		return "Hook"
+	case CmdCatchDone:
+		return "CatchDone"
+	case CmdStrike:
+		return "Strike"
+	case CmdLand:
+		return "Land"
+	case CmdLineBreak:
+		return "LineBreak"
+	default:
+		return "Unknown"
+	}
+}
+
+// command es un comando en la cola de la máquina de estados
+type command struct {
+	cmd      Command
+	castSeq  int      // Lanzamiento al que se refiere CmdCatchDone
+	fishType FishType // Especie del pez enganchado con CmdHook
+}
+
+// stateTransitions son las reglas explícitas: estado actual -> comando -> estado siguiente.
+// Cualquier combinación que no aparezca aquí se rechaza.
+var stateTransitions = map[GameState]map[Command]GameState{
+	StatePlaying: {
+		CmdCast: StateFishing,
+	},
+	StateFishing: {
+		CmdReel:   StatePlaying,
+		CmdHook:   StateCaught,
+		CmdStrike: StateFighting,
+	},
+	StateFighting: {
+		CmdReel:      StatePlaying, // Soltar al pez
+		CmdLand:      StateCaught,
+		CmdLineBreak: StatePlaying,
+	},
+	StateCaught: {
+		CmdCatchDone: StatePlaying,
+	},
+}
+
+// Transition es una entrada del log de transiciones
+type Transition struct {
+	Frame    int
+	Command  Command
+	From     GameState
+	To       GameState
+	Accepted bool
+}
+
+// String formatea la transición para depuración
+func (t Transition) String() string {
+	if !t.Accepted {
+		return fmt.Sprintf("#%d %s: %s (rechazado)", t.Frame, t.Command, t.From)
+	}
+	return fmt.Sprintf("#%d %s: %s -> %s", t.Frame, t.Command, t.From, t.To)
+}
+
+// State retorna el estado actual del juego
+func (g *Game) State() GameState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state
+}
+
+// Cast pide lanzar el anzuelo. El comando se aplica en el siguiente Step.
+func (g *Game) Cast() {
+	g.sendCommand(command{cmd: CmdCast})
+}
+
+// Reel pide recoger el anzuelo. El comando se aplica en el siguiente Step.
+func (g *Game) Reel() {
+	g.sendCommand(command{cmd: CmdReel})
+}
+
+// sendCommand encola un comando para la goroutine dueña del estado.
+// Puede llamarse desde cualquier goroutine.
+func (g *Game) sendCommand(c command) {
+	select {
+	case g.commands <- c:
+	case <-g.ctx.Done():
+	}
+}
+
+// processCommands aplica los comandos encolados sin bloquear
+func (g *Game) processCommands() {
+	for {
+		select {
+		case c := <-g.commands:
+			g.dispatch(c)
+		default:
+			return
+		}
+	}
+}
+
+// dispatch aplica un comando si la transición es válida.
+// IMPORTANTE: sólo debe llamarse desde la goroutine de Update/Step (único escritor).
+func (g *Game) dispatch(c command) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	from := g.state
+	to, ok := stateTransitions[from][c.cmd]
+
+	// Condiciones adicionales de cada comando
+	switch c.cmd {
+	case CmdCast:
+		ok = ok && g.player.IsNearWater()
+	case CmdCatchDone:
+		// Sólo resetear si el lanzamiento que capturó sigue vigente
+		ok = ok && c.castSeq == g.castSeq
+	}
+
+	g.logTransition(Transition{Frame: g.frameCount, Command: c.cmd, From: from, To: to, Accepted: ok})
+	if !ok {
+		return false
+	}
+
+	switch c.cmd {
+	case CmdCast:
+		g.castSeq++
+		g.player.Cast()
+		tipX, tipY, _ := g.player.RodTip()
+		toX, toY := castTarget(g.player.X, g.player.Y, g.cfg.CastDistance)
+		dx, dy := g.weather.CastOffset() // El viento desvía el lanzamiento
+		toX, toY = clampToLake(toX+dx, toY+dy)
+		g.bobber.Cast(tipX, tipY, toX, toY)
+		g.line.Reset()
+		g.bus.Publish(GameEvent{Type: EventCast})
+
+	case CmdReel:
+		g.bobber.Reset()
+		g.player.StopFishing()
+		g.bus.Publish(GameEvent{Type: EventReel})
+		if from == StateFighting {
+			g.releaseLegendary("Soltaste al pez legendario")
+		}
+
+	case CmdHook:
+		// IMPORTANTE: Desactivar bobber INMEDIATAMENTE para evitar múltiples capturas
+		g.bobber.active = false
+		g.bobber.SetState(BobberCaught)
+		g.particles.EmitCatchSplash(g.bobber.X, g.bobber.Y)
+		g.ecology.Caught(c.fishType) // Sólo las capturas reales reducen la abundancia
+
+		// Iniciar goroutine para resetear después de captura
+		g.wg.Add(1)
+		go g.resetAfterCatch(g.castSeq)
+
+	case CmdStrike:
+		// El bobber deja de detectar peces mientras dura la pelea
+		g.bobber.active = false
+		g.bobber.SetState(BobberBite)
+		g.particles.EmitCatchSplash(g.bobber.X, g.bobber.Y)
+		g.encounter.strike()
+
+	case CmdLand:
+		g.bobber.SetState(BobberCaught)
+		g.particles.EmitCatchSplash(g.bobber.X, g.bobber.Y)
+		g.encounter.end("¡Capturaste al pez legendario!")
+		g.ecology.Caught(FishLegendary)
+
+		g.wg.Add(1)
+		go g.resetAfterCatch(g.castSeq)
+
+	case CmdLineBreak:
+		g.bobber.Reset()
+		g.player.StopFishing()
+		g.releaseLegendary("¡Se rompió la línea!")
+
+	case CmdCatchDone:
+		g.bobber.Reset()
+		g.player.StopFishing()
+	}
+
+	g.state = to
+	g.bus.Publish(GameEvent{Type: EventStateChanged, PrevState: from, State: to})
+	return true
+}
+
+// logTransition guarda la transición en el buffer circular
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) logTransition(t Transition) {
+	g.transitionLog[g.transitionN%transitionLogSize] = t
+	g.transitionN++
+}
+
+// TransitionLog retorna las últimas transiciones, de la más antigua a la más reciente
+func (g *Game) TransitionLog() []Transition {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.recentTransitions(make([]Transition, 0, min(g.transitionN, transitionLogSize)), transitionLogSize)
+}
+
+// recentTransitions agrega a dst las últimas n transiciones (a lo sumo
+// transitionLogSize), de la más antigua a la más reciente
+// IMPORTANTE: debe llamarse con g.mu tomado
+func (g *Game) recentTransitions(dst []Transition, n int) []Transition {
+	n = min(n, g.transitionN, transitionLogSize)
+	for i := g.transitionN - n; i < g.transitionN; i++ {
+		dst = append(dst, g.transitionLog[i%transitionLogSize])
+	}
+	return dst
+}
+
+// resetAfterCatch espera la pausa de captura y pide volver al modo de juego normal.
+// No toca el estado: envía CmdCatchDone a la goroutine dueña.
+func (g *Game) resetAfterCatch(castSeq int) {
+	defer g.wg.Done()
+
+	select {
+	case <-time.After(g.cfg.CatchResetDelay.Duration):
+	case <-g.ctx.Done():
+		return
+	}
+
+	g.sendCommand(command{cmd: CmdCatchDone, castSeq: castSeq})
+}
@@ -0,0 +1,106 @@
+package game
+
+import "testing"
+
+// newStepGame crea un juego con el spawner detenido y un lago fijo de peces,
+// así cada Step hace el mismo trabajo (sin goroutines de peces que lo muevan)
+func newStepGame(tb testing.TB) *Game {
+	tb.Helper()
+	g := newTestGame(tb, DefaultConfig())
+	stopTestSpawner(g)
+
+	g.mu.Lock()
+	for i := 0; i < 20; i++ {
+		fishType := FishType(i % int(numFishTypes-1)) // Sin legendarios: no empiezan encuentros
+		g.fishes = append(g.fishes, NewFish(LakeCenterX+float64(i*8-80), LakeCenterY, fishType, g.rng, g.conditions))
+	}
+	g.mu.Unlock()
+	return g
+}
+
+func TestStepAllocs(t *testing.T) {
+	g := newStepGame(t)
+	for i := 0; i < 2*metricsSampleTicks; i++ {
+		g.Step() // Calentar: buffers de métricas, historial, etc.
+	}
+	if allocs := testing.AllocsPerRun(1000, g.Step); allocs != 0 {
+		t.Errorf("Step allocs = %v, want 0", allocs)
+	}
+}
+
+// Con el anzuelo en el agua Step también busca capturas
+func TestStepFishingAllocs(t *testing.T) {
+	g := newStepGame(t)
+	g.Cast()
+	stepUntil(t, g, "bobber in the water", func() bool { return bobberActive(g) })
+	for i := 0; i < 2*metricsSampleTicks; i++ {
+		g.Step()
+	}
+	if s := g.State(); s != StateFishing {
+		t.Fatalf("state = %v, want %v", s, StateFishing)
+	}
+	if allocs := testing.AllocsPerRun(1000, g.Step); allocs != 0 {
+		t.Errorf("Step while fishing allocs = %v, want 0", allocs)
+	}
+}
+
+func TestCleanupFishesAllocs(t *testing.T) {
+	g := newStepGame(t)
+	g.mu.Lock()
+	far := g.fishes[0]
+	far.mu.Lock()
+	far.X, far.Y = -1000, -1000 // Fuera del lago: se elimina en la primera pasada
+	far.mu.Unlock()
+	g.mu.Unlock()
+
+	if allocs := testing.AllocsPerRun(100, g.cleanupFishes); allocs != 0 {
+		t.Errorf("cleanupFishes allocs = %v, want 0", allocs)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, fish := range g.fishes {
+		if fish == far {
+			t.Error("fish outside the lake was not removed")
+		}
+	}
+}
+
+func TestHUDCacheAllocs(t *testing.T) {
+	var h hudCache
+	v := hudValues{score: 120, fishCaught: 7, common: 5, rare: 2}
+	h.update(v)
+	if allocs := testing.AllocsPerRun(1000, func() { h.update(v) }); allocs != 0 {
+		t.Errorf("hudCache.update with unchanged values allocs = %v, want 0", allocs)
+	}
+
+	v.score++
+	h.update(v)
+	if h.lines[0] != "Puntos: 121" {
+		t.Errorf("HUD score line = %q after a change", h.lines[0])
+	}
+}
+
+func TestParticleSystemAllocs(t *testing.T) {
+	g := newStepGame(t)
+	ps := NewParticleSystem(g.rng)
+
+	// Muchas partículas por tick con vidas largas: el pool se llena y
+	// también se cubre el descarte cuando no queda lugar
+	allocs := testing.AllocsPerRun(1000, func() {
+		ps.EmitCatchSplash(100, 100)
+		ps.EmitSparkle(100, 100)
+		ps.Update()
+	})
+	if allocs != 0 {
+		t.Errorf("ParticleSystem emit/Update allocs = %v, want 0", allocs)
+	}
+}
+
+func BenchmarkStep(b *testing.B) {
+	g := newStepGame(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Step()
+	}
+}
@@ -0,0 +1,218 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// WeatherKind es el estado del clima
+type WeatherKind int
+
+const (
+	WeatherClear WeatherKind = iota
+	WeatherRain
+	WeatherWind
+	WeatherFog
+	numWeathers
+)
+
+// String retorna el nombre del clima (se muestra en el HUD)
+func (k WeatherKind) String() string {
+	switch k {
+	case WeatherClear:
+		return "Despejado"
+	case WeatherRain:
+		return "Lluvia"
+	case WeatherWind:
+		return "Viento"
+	case WeatherFog:
+		return "Niebla"
+	default:
+		return "?"
+	}
+}
+
+// Duración de cada clima y del cambio gradual de intensidad (en ticks)
+const (
+	weatherMinTicks  = 45 * ebiten.DefaultTPS
+	weatherMaxTicks  = 120 * ebiten.DefaultTPS
+	weatherFadeTicks = 5 * ebiten.DefaultTPS
+)
+
+// Efectos del clima
+const (
+	rainBiteBonus     = 0.3  // La lluvia agranda el radio de captura hasta un 30%
+	rainDropsPerTick  = 0.8  // Ondas de gotas sobre el lago por tick con lluvia plena
+	rainStreaks       = 60   // Trazos de lluvia en pantalla
+	windMaxDrift      = 0.12 // Deriva máxima del bobber en píxeles por tick
+	windCastPush      = 15.0 // Píxeles que el viento pleno desplaza un lanzamiento
+	windCastScatter   = 12.0 // Imprecisión aleatoria del lanzamiento con viento pleno
+	fogMinVisibility  = 0.35 // Opacidad mínima de los peces con niebla plena
+	fogOverlayOpacity = 90   // Opacidad (0-255) de la capa de niebla
+)
+
+// Probabilidad de pasar de un clima (fila) a otro (columna)
+var weatherTransitions = [numWeathers][numWeathers]float64{
+	WeatherClear: {0.4, 0.25, 0.2, 0.15},
+	WeatherRain:  {0.5, 0.2, 0.2, 0.1},
+	WeatherWind:  {0.5, 0.25, 0.15, 0.1},
+	WeatherFog:   {0.6, 0.2, 0.1, 0.1},
+}
+
+// Multiplicadores de la probabilidad de aparición y de la velocidad de nado
+// de cada especie según el clima (se combinan con los de la fase del día)
+var weatherSpawnWeights = [numWeathers][numFishTypes]float64{
+	WeatherClear: {1, 1, 1, 1},
+	WeatherRain:  {0.9, 1.3, 1.4, 1.2},
+	WeatherWind:  {1.1, 0.9, 0.9, 1},
+	WeatherFog:   {0.9, 1, 1.2, 1.6},
+}
+
+var weatherSwimSpeeds = [numWeathers][numFishTypes]float64{
+	WeatherClear: {1, 1, 1, 1},
+	WeatherRain:  {1.15, 1.15, 1.15, 1.15},
+	WeatherWind:  {1, 1, 1, 1},
+	WeatherFog:   {0.85, 0.85, 0.85, 0.85},
+}
+
+var (
+	colorRain = color.RGBA{150, 170, 200, 150}
+	colorFog  = color.RGBA{fogOverlayOpacity, fogOverlayOpacity, fogOverlayOpacity, fogOverlayOpacity}
+)
+
+// Weather es el clima actual. Cambia cada cierto tiempo según una cadena
+// de Markov con su propio generador aleatorio, así que con la misma semilla
+// la secuencia de climas se repite. Sólo la goroutine de Update lo usa
+// (con g.mu tomado).
+type Weather struct {
+	kind      WeatherKind
+	ticksLeft int     // Ticks hasta el próximo cambio
+	intensity float64 // Sube de 0 a 1 durante weatherFadeTicks al cambiar
+	ticks     int
+
+	// Viento: dirección (radianes) y fuerza (0 a 1)
+	windAngle    float64
+	windStrength float64
+
+	rng *rand.Rand
+}
+
+// NewWeather crea el clima inicial (despejado)
+func NewWeather(rng *rand.Rand) *Weather {
+	w := &Weather{rng: rand.New(rand.NewSource(rng.Int63()))}
+	w.start(WeatherClear)
+	w.intensity = 1
+	return w
+}
+
+// start comienza un clima nuevo con una duración aleatoria
+func (w *Weather) start(kind WeatherKind) {
+	w.kind = kind
+	w.ticksLeft = weatherMinTicks + w.rng.Intn(weatherMaxTicks-weatherMinTicks)
+	w.intensity = 0
+	if kind == WeatherWind {
+		w.windAngle = w.rng.Float64() * 2 * math.Pi
+		w.windStrength = 0.4 + w.rng.Float64()*0.6
+	}
+}
+
+// Tick avanza el clima un tick. Retorna true si cambió.
+func (w *Weather) Tick() bool {
+	w.ticks++
+	w.intensity = math.Min(w.intensity+1.0/weatherFadeTicks, 1)
+
+	w.ticksLeft--
+	if w.ticksLeft > 0 {
+		return false
+	}
+
+	roll := w.rng.Float64()
+	next := WeatherClear
+	for k, p := range weatherTransitions[w.kind] {
+		roll -= p
+		if roll < 0 {
+			next = WeatherKind(k)
+			break
+		}
+	}
+	w.start(next)
+	return true
+}
+
+// Kind retorna el clima actual
+func (w *Weather) Kind() WeatherKind {
+	return w.kind
+}
+
+// strength retorna la intensidad actual si el clima es kind, si no 0
+func (w *Weather) strength(kind WeatherKind) float64 {
+	if w.kind != kind {
+		return 0
+	}
+	return w.intensity
+}
+
+// BiteRadiusScale retorna el multiplicador del radio de captura (la lluvia lo agranda)
+func (w *Weather) BiteRadiusScale() float64 {
+	return 1 + rainBiteBonus*w.strength(WeatherRain)
+}
+
+// FishVisibility retorna la opacidad con que se ven los peces (la niebla la reduce)
+func (w *Weather) FishVisibility() float64 {
+	return 1 - (1-fogMinVisibility)*w.strength(WeatherFog)
+}
+
+// Drift retorna cuánto mueve el viento al bobber en un tick
+func (w *Weather) Drift() (float64, float64) {
+	f := windMaxDrift * w.windStrength * w.strength(WeatherWind)
+	return math.Cos(w.windAngle) * f, math.Sin(w.windAngle) * f
+}
+
+// CastOffset retorna cuánto desvía el viento un lanzamiento:
+// un empuje en la dirección del viento más una imprecisión aleatoria
+func (w *Weather) CastOffset() (float64, float64) {
+	s := w.windStrength * w.strength(WeatherWind)
+	if s == 0 {
+		return 0, 0
+	}
+	push := windCastPush * s
+	scatterX := (w.rng.Float64()*2 - 1) * windCastScatter * s
+	scatterY := (w.rng.Float64()*2 - 1) * windCastScatter * s
+	return math.Cos(w.windAngle)*push + scatterX, math.Sin(w.windAngle)*push + scatterY
+}
+
+// EmitEffects agrega las gotas de lluvia que caen sobre el lago
+func (w *Weather) EmitEffects(ps *ParticleSystem) {
+	rain := w.strength(WeatherRain)
+	if rain == 0 || w.rng.Float64() >= rainDropsPerTick*rain {
+		return
+	}
+	angle := w.rng.Float64() * 2 * math.Pi
+	radius := math.Sqrt(w.rng.Float64()) * (LakeRadius - 10)
+	ps.EmitRaindrop(LakeCenterX+radius*math.Cos(angle), LakeCenterY+radius*math.Sin(angle))
+}
+
+// DrawFog dibuja la capa de niebla sobre el lago y los peces
+func (w *Weather) DrawFog(screen *ebiten.Image) {
+	if fog := w.strength(WeatherFog); fog > 0 {
+		fillRect(screen, 0, 0, ScreenWidth, ScreenHeight, fadeColor(colorFog, fog))
+	}
+}
+
+// DrawRain dibuja los trazos de lluvia sobre toda la escena. Las posiciones
+// se derivan del tick para no guardar estado ni reservar memoria.
+func (w *Weather) DrawRain(screen *ebiten.Image) {
+	rain := w.strength(WeatherRain)
+	if rain == 0 {
+		return
+	}
+	clr := fadeColor(colorRain, rain)
+	for i := 0; i < rainStreaks; i++ {
+		x := float64((i*97 + w.ticks*2) % ScreenWidth)
+		y := float64((i*151 + w.ticks*9) % ScreenHeight)
+		drawLine(screen, x, y, x-2, y+8, 1, clr)
+	}
+}
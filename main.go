@@ -1,31 +1,135 @@
 package main
 
 import (
+	"embed"
 	"fishing-game/game"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-const (
-	screenWidth  = 640
-	screenHeight = 480
-)
+// Assets embebidos en el binario; -asset-dir permite reemplazarlos desde disco
+//
+//go:embed assets
+var embeddedAssets embed.FS
 
 func main() {
+	defaults := game.DefaultConfig()
+
+	configPath := flag.String("config", "", "archivo de configuración JSON")
+	seed := flag.Int64("seed", defaults.Seed, "semilla aleatoria (0 = aleatoria)")
+	difficulty := flag.String("difficulty", defaults.Difficulty, "dificultad: easy, normal o hard")
+	width := flag.Int("width", defaults.WindowWidth, "ancho de la ventana")
+	height := flag.Int("height", defaults.WindowHeight, "alto de la ventana")
+	fullscreen := flag.Bool("fullscreen", defaults.Fullscreen, "pantalla completa")
+	vsync := flag.Bool("vsync", defaults.VSync, "sincronización vertical")
+	assetDir := flag.String("asset-dir", defaults.AssetDir, "directorio cuyos assets reemplazan a los embebidos (mods)")
+	hotReload := flag.Bool("hot-reload", defaults.HotReload, "recargar sprites al modificar -asset-dir")
+	saveDir := flag.String("save-dir", defaults.SaveDir, "directorio de partidas guardadas y logros")
+	metricsAddr := flag.String("metrics-addr", "", "dirección para servir métricas de Prometheus (ej. localhost:9090)")
+	profileDir := flag.String("profile-dir", "profiles", "directorio donde se escriben perfiles y trazas (F5/F6)")
+	profile := flag.Bool("profile", false, "capturar perfil de CPU y traza desde el inicio hasta cerrar el juego")
+	flag.Parse()
+
+	// Configuración: valores por defecto, luego el archivo y por último las opciones explícitas
+	cfg := defaults
+	if *configPath != "" {
+		var err error
+		if cfg, err = game.LoadConfig(*configPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "seed":
+			cfg.Seed = *seed
+		case "difficulty":
+			cfg.Difficulty = *difficulty
+		case "width":
+			cfg.WindowWidth = *width
+		case "height":
+			cfg.WindowHeight = *height
+		case "fullscreen":
+			cfg.Fullscreen = *fullscreen
+		case "vsync":
+			cfg.VSync = *vsync
+		case "asset-dir":
+			cfg.AssetDir = *assetDir
+		case "hot-reload":
+			cfg.HotReload = *hotReload
+		case "save-dir":
+			cfg.SaveDir = *saveDir
+		}
+	})
+	assets, err := fs.Sub(embeddedAssets, "assets")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg.Assets = assets
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid configuration:")
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Perfilador: siempre disponible con F5/F6, activo desde el inicio con -profile
+	prof, err := game.NewProfiler(*profileDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *profile {
+		if err := prof.Start(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// Crear el juego
-	g, err := game.NewGame()
+	g, err := game.NewGame(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
+	g.SetProfiler(prof)
+
+	// Servidor de métricas opcional
+	if *metricsAddr != "" {
+		if err := g.StartMetricsServer(*metricsAddr); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("metrics: http://%s/metrics", g.MetricsAddr())
+	}
 
 	// Configurar ventana
-	ebiten.SetWindowSize(screenWidth, screenHeight)
-	ebiten.SetWindowTitle("Fishing Game - Concurrent Programming")
+	ebiten.SetWindowSize(cfg.WindowWidth, cfg.WindowHeight)
+	ebiten.SetWindowTitle(cfg.WindowTitle)
+	ebiten.SetFullscreen(cfg.Fullscreen)
+	ebiten.SetVsyncEnabled(cfg.VSync)
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowClosingHandled(true) // El juego decide cuándo cerrar
 
 	// Ejecutar el juego
-	if err := ebiten.RunGame(g); err != nil {
-		log.Fatal(err)
+	runErr := ebiten.RunGame(g)
+
+	// Cierre ordenado: detener goroutines, procesar capturas y guardar
+	if err := g.Shutdown(game.DefaultShutdownTimeout); err != nil {
+		log.Println("shutdown:", err)
+	}
+
+	// Cerrar capturas en curso (incluyen el cierre ordenado) y guardar el heap final
+	if *profile {
+		if _, err := prof.WriteHeap(); err != nil {
+			log.Println("heap profile:", err)
+		}
+	}
+	if err := prof.Stop(); err != nil {
+		log.Println("profiling:", err)
+	}
+
+	if runErr != nil {
+		log.Fatal(runErr)
 	}
-}
\ No newline at end of file
+}